@@ -0,0 +1,293 @@
+package fifty2
+
+import (
+	"fmt"
+)
+
+type DealAction uint8
+
+const (
+	DealDown DealAction = iota
+	DealUp
+	DealBurn
+	DealBoard
+)
+
+func (da DealAction) String() string {
+	switch da {
+	case DealDown:
+		return "down"
+	case DealUp:
+		return "up"
+	case DealBurn:
+		return "burn"
+	case DealBoard:
+		return "board"
+	}
+	return ""
+}
+
+// DealStep deals Count cards. Down and up steps go round-robin, one card to
+// each seat per pass; burn and board steps deal Count cards once.
+type DealStep struct {
+	Action DealAction `json:"action"`
+	Count  int        `json:"count"`
+}
+
+// DealRound is the group of steps dealt before a betting round.
+type DealRound []DealStep
+
+type DealScript []DealRound
+
+func (ds DealScript) CardsPerSeat() int {
+	cards := 0
+	for _, round := range ds {
+		for _, step := range round {
+			if step.Action == DealDown || step.Action == DealUp {
+				cards += step.Count
+			}
+		}
+	}
+	return cards
+}
+
+func (ds DealScript) BoardSize() int {
+	cards := 0
+	for _, round := range ds {
+		for _, step := range round {
+			if step.Action == DealBoard {
+				cards += step.Count
+			}
+		}
+	}
+	return cards
+}
+
+// BoardSizes returns the size of the board before the first round and after
+// each round that deals community cards.
+func (ds DealScript) BoardSizes() []int {
+	sizes := []int{0}
+	board := 0
+	for _, round := range ds {
+		for _, step := range round {
+			if step.Action == DealBoard {
+				board += step.Count
+			}
+		}
+		if board != sizes[len(sizes)-1] {
+			sizes = append(sizes, board)
+		}
+	}
+	return sizes
+}
+
+// FaceUp returns whether each card a seat is dealt, in the order dealt, is
+// dealt face up, as with the upcards in stud.
+func (ds DealScript) FaceUp() []bool {
+	faceUp := make([]bool, 0, ds.CardsPerSeat())
+	for _, round := range ds {
+		for _, step := range round {
+			if step.Action == DealDown || step.Action == DealUp {
+				for i := 0; i < step.Count; i++ {
+					faceUp = append(faceUp, step.Action == DealUp)
+				}
+			}
+		}
+	}
+	return faceUp
+}
+
+func (ds DealScript) Cards(seats int) int {
+	cards := 0
+	for _, round := range ds {
+		cards += round.cards(seats)
+	}
+	return cards
+}
+
+func (dr DealRound) cards(seats int) int {
+	cards := 0
+	for _, step := range dr {
+		switch step.Action {
+		case DealDown, DealUp:
+			cards += step.Count * seats
+		case DealBurn, DealBoard:
+			cards += step.Count
+		}
+	}
+	return cards
+}
+
+type DealtCard struct {
+	Card
+	FaceUp bool
+}
+
+type Dealer struct {
+	script DealScript
+	deck   []Card
+	next   int
+	round  int
+	seats  [][]DealtCard
+	board  []Card
+	burned []Card
+	rng    *Source
+	key    []byte
+}
+
+func NewDealer(script DealScript, seats int, deck []Card) *Dealer {
+	if seats < 1 {
+		panic("fifty2: dealer needs at least one seat")
+	}
+	d := &Dealer{
+		script: script,
+		deck:   make([]Card, len(deck)),
+		seats:  make([][]DealtCard, seats),
+		board:  make([]Card, 0, script.BoardSize()),
+		burned: make([]Card, 0),
+	}
+	copy(d.deck, deck)
+	for i := range d.seats {
+		d.seats[i] = make([]DealtCard, 0, script.CardsPerSeat())
+	}
+	return d
+}
+
+// Shuffle shuffles the undealt cards with src. The dealer keeps src so its
+// state is saved along with the rest of the deal.
+func (d *Dealer) Shuffle(src *Source) {
+	d.rng = src
+	ShuffleWith(d.deck[d.next:], src)
+}
+
+func (d *Dealer) Source() *Source {
+	return d.rng
+}
+
+func (d *Dealer) Done() bool {
+	return d.round >= len(d.script)
+}
+
+// Round returns the number of rounds dealt so far.
+func (d *Dealer) Round() int {
+	return d.round
+}
+
+func (d *Dealer) Seats() int {
+	return len(d.seats)
+}
+
+// Deal deals the next round of the script. A round is dealt completely or
+// not at all.
+func (d *Dealer) Deal() error {
+	if d.Done() {
+		return fmt.Errorf("fifty2: all %d rounds have been dealt", len(d.script))
+	}
+
+	round := d.script[d.round]
+	if need := round.cards(len(d.seats)); need > len(d.deck)-d.next {
+		return fmt.Errorf("fifty2: round %d needs %d cards, %d left in deck", d.round+1, need, len(d.deck)-d.next)
+	}
+
+	for _, step := range round {
+		switch step.Action {
+		case DealDown, DealUp:
+			for pass := 0; pass < step.Count; pass++ {
+				for seat := range d.seats {
+					d.seats[seat] = append(d.seats[seat], DealtCard{d.draw(), step.Action == DealUp})
+				}
+			}
+		case DealBurn:
+			for i := 0; i < step.Count; i++ {
+				d.burned = append(d.burned, d.draw())
+			}
+		case DealBoard:
+			for i := 0; i < step.Count; i++ {
+				d.board = append(d.board, d.draw())
+			}
+		}
+	}
+	d.round++
+
+	return nil
+}
+
+func (d *Dealer) DealAll() error {
+	for !d.Done() {
+		if err := d.Deal(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dealer) draw() Card {
+	card := d.deck[d.next]
+	d.next++
+	return card
+}
+
+// Hand returns every card dealt to seat, face up or down.
+func (d *Dealer) Hand(seat int) []Card {
+	hand := make([]Card, len(d.seats[seat]))
+	for i, dc := range d.seats[seat] {
+		hand[i] = dc.Card
+	}
+	return hand
+}
+
+func (d *Dealer) DealtCards(seat int) []DealtCard {
+	dealt := make([]DealtCard, len(d.seats[seat]))
+	copy(dealt, d.seats[seat])
+	return dealt
+}
+
+func (d *Dealer) Board() []Card {
+	board := make([]Card, len(d.board))
+	copy(board, d.board)
+	return board
+}
+
+func (d *Dealer) Burned() []Card {
+	burned := make([]Card, len(d.burned))
+	copy(burned, d.burned)
+	return burned
+}
+
+// Remaining returns the undealt cards in the order they will be dealt.
+func (d *Dealer) Remaining() []Card {
+	remaining := make([]Card, len(d.deck)-d.next)
+	copy(remaining, d.deck[d.next:])
+	return remaining
+}
+
+// SeatView is the table as seen from one seat: its own cards, the board and
+// only the face up cards of the other seats.
+type SeatView struct {
+	Seat    int
+	Hand    []DealtCard
+	Board   []Card
+	Upcards [][]Card
+	Hidden  []int
+}
+
+func (d *Dealer) View(seat int) SeatView {
+	view := SeatView{
+		Seat:    seat,
+		Hand:    d.DealtCards(seat),
+		Board:   d.Board(),
+		Upcards: make([][]Card, len(d.seats)),
+		Hidden:  make([]int, len(d.seats)),
+	}
+	for i, dealt := range d.seats {
+		view.Upcards[i] = make([]Card, 0, len(dealt))
+		for _, dc := range dealt {
+			if dc.FaceUp {
+				view.Upcards[i] = append(view.Upcards[i], dc.Card)
+			} else if i != seat {
+				view.Hidden[i]++
+			}
+		}
+	}
+	return view
+}
@@ -0,0 +1,110 @@
+package fifty2
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testHoldemDeal = DealScript{
+	DealRound{DealStep{DealDown, 2}},
+	DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 3}},
+	DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+	DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+}
+
+var testStudDeal = DealScript{
+	DealRound{DealStep{DealDown, 2}, DealStep{DealUp, 1}},
+	DealRound{DealStep{DealBurn, 1}, DealStep{DealUp, 1}},
+	DealRound{DealStep{DealBurn, 1}, DealStep{DealDown, 1}},
+}
+
+func TestDealScript(t *testing.T) {
+	if n := testHoldemDeal.CardsPerSeat(); n != 2 {
+		t.Errorf("cards per seat %d != 2", n)
+	}
+	if n := testHoldemDeal.BoardSize(); n != 5 {
+		t.Errorf("board size %d != 5", n)
+	}
+	if n := testHoldemDeal.Cards(3); n != 14 {
+		t.Errorf("cards for 3 seats %d != 14", n)
+	}
+	if sizes := testHoldemDeal.BoardSizes(); !reflect.DeepEqual(sizes, []int{0, 3, 4, 5}) {
+		t.Errorf("board sizes %v != [0 3 4 5]", sizes)
+	}
+	if sizes := testStudDeal.BoardSizes(); !reflect.DeepEqual(sizes, []int{0}) {
+		t.Errorf("board sizes %v != [0]", sizes)
+	}
+	if faceUp := testStudDeal.FaceUp(); !reflect.DeepEqual(faceUp, []bool{false, false, true, true, false}) {
+		t.Errorf("face up %v != [false false true true false]", faceUp)
+	}
+	if faceUp := testHoldemDeal.FaceUp(); !reflect.DeepEqual(faceUp, []bool{false, false}) {
+		t.Errorf("face up %v != [false false]", faceUp)
+	}
+}
+
+func TestDealerHoldem(t *testing.T) {
+	deck := NewDeck()
+	dealer := NewDealer(testHoldemDeal, 2, deck)
+
+	if err := dealer.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	if hand := dealer.Hand(0); !reflect.DeepEqual(hand, []Card{deck[0], deck[2]}) {
+		t.Errorf("seat 1 not dealt round-robin - %v", hand)
+	}
+	if hand := dealer.Hand(1); !reflect.DeepEqual(hand, []Card{deck[1], deck[3]}) {
+		t.Errorf("seat 2 not dealt round-robin - %v", hand)
+	}
+
+	if err := dealer.DealAll(); err != nil {
+		t.Fatal(err)
+	}
+	if board := dealer.Board(); !reflect.DeepEqual(board, []Card{deck[5], deck[6], deck[7], deck[9], deck[11]}) {
+		t.Errorf("incorrect board - %v", board)
+	}
+	if burned := dealer.Burned(); !reflect.DeepEqual(burned, []Card{deck[4], deck[8], deck[10]}) {
+		t.Errorf("incorrect burn pile - %v", burned)
+	}
+	if remaining := dealer.Remaining(); !reflect.DeepEqual(remaining, deck[12:]) {
+		t.Errorf("incorrect remaining deck - %v", remaining)
+	}
+
+	if err := dealer.Deal(); err == nil {
+		t.Errorf("dealt past the end of the script")
+	}
+}
+
+func TestDealerView(t *testing.T) {
+	deck := NewDeck()
+	dealer := NewDealer(testStudDeal, 2, deck)
+	if err := dealer.DealAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	view := dealer.View(0)
+	if len(view.Hand) != 5 {
+		t.Errorf("seat 1 should see all 5 of its cards - %v", view.Hand)
+	}
+	if !reflect.DeepEqual(view.Upcards[1], []Card{deck[5], deck[8]}) {
+		t.Errorf("incorrect upcards for seat 2 - %v", view.Upcards[1])
+	}
+	if view.Hidden[0] != 0 || view.Hidden[1] != 3 {
+		t.Errorf("incorrect hidden card counts - %v", view.Hidden)
+	}
+	if view.Hand[2].Card != deck[4] || !view.Hand[2].FaceUp || view.Hand[4].FaceUp {
+		t.Errorf("incorrect visibility - %v", view.Hand)
+	}
+}
+
+func TestDealerExhausted(t *testing.T) {
+	dealer := NewDealer(testHoldemDeal, 2, NewDeck()[:6])
+	if err := dealer.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dealer.Deal(); err == nil {
+		t.Errorf("dealt the flop from a 2 card deck")
+	}
+	if len(dealer.Board()) != 0 || len(dealer.Remaining()) != 2 {
+		t.Errorf("partial round dealt")
+	}
+}
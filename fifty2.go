@@ -2,6 +2,7 @@ package fifty2
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"math/rand"
@@ -69,6 +70,8 @@ const (
 	Jack
 	Queen
 	King
+	// Joker is only in decks from NewJokerDeck. Jokers are told apart by suit.
+	Joker
 )
 
 func ParseRank(r rune) (Rank, error) {
@@ -99,6 +102,8 @@ func ParseRank(r rune) (Rank, error) {
 		return Queen, nil
 	case 'k', 'K':
 		return King, nil
+	case 'x', 'X':
+		return Joker, nil
 	}
 	return 0, fmt.Errorf("fifty2: unknown rank[%c]", r)
 }
@@ -131,6 +136,8 @@ func (r Rank) Rune() rune {
 		return 'Q'
 	case King:
 		return 'K'
+	case Joker:
+		return 'X'
 	}
 	return 0
 }
@@ -156,6 +163,22 @@ func (c Card) String() string {
 	return fmt.Sprintf("%c%c", c.Rank.Rune(), c.Suit.Rune())
 }
 
+func (c Card) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Card) UnmarshalText(text []byte) error {
+	cards, err := NewCardReader(bytes.NewReader(text)).ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(cards) != 1 {
+		return fmt.Errorf("fifty2: expected a single card[%s]", text)
+	}
+	*c = cards[0]
+	return nil
+}
+
 type CardReader struct {
 	reader *bufio.Reader
 }
@@ -214,6 +237,31 @@ func NewDeck() []Card {
 	return deck
 }
 
+// NewShortDeck returns the 36 card deck used in short deck (6+) poker, with
+// the twos through fives removed.
+func NewShortDeck() []Card {
+	deck := make([]Card, 0, 36)
+	for _, card := range NewDeck() {
+		if card.Rank == Ace || card.Rank >= Six {
+			deck = append(deck, card)
+		}
+	}
+	return deck
+}
+
+// NewJokerDeck returns a standard deck with jokers added, at most one of
+// each suit.
+func NewJokerDeck(jokers int) []Card {
+	if jokers < 0 || jokers > 4 {
+		panic("fifty2: a deck has at most 4 jokers")
+	}
+	deck := NewDeck()
+	for _, suit := range Suits()[:jokers] {
+		deck = append(deck, Card{Rank: Joker, Suit: suit})
+	}
+	return deck
+}
+
 func NewDeckSet(decks uint) []Card {
 	deckSet := make([]Card, 52*decks)
 	for d := uint(0); d < decks; d++ {
@@ -232,6 +280,20 @@ func Mask(slice []Card) uint64 {
 	return mask
 }
 
+// FromMask returns the cards in mask, the inverse of Mask.
+func FromMask(mask uint64) []Card {
+	cards := make([]Card, 0, 52)
+	for _, rank := range append(Ranks(), Joker) {
+		for _, suit := range Suits() {
+			card := Card{rank, suit}
+			if mask&card.Mask() != 0 {
+				cards = append(cards, card)
+			}
+		}
+	}
+	return cards
+}
+
 func Shuffle(slice []Card) {
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	for src := 0; src < len(slice); src++ {
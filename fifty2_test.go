@@ -136,3 +136,26 @@ func TestMultipleCombinations(t *testing.T) {
 	}
 
 }
+
+func TestJokerDeck(t *testing.T) {
+	deck := NewJokerDeck(2)
+	if len(deck) != 54 || deck[52] != (Card{Joker, Clubs}) || deck[53] != (Card{Joker, Diamonds}) {
+		t.Errorf("incorrect joker deck - %v", deck[50:])
+	}
+	if cards := FromMask(Mask(deck)); len(cards) != 54 || Mask(cards) != Mask(deck) {
+		t.Errorf("joker deck mask round trip - %v", cards)
+	}
+
+	joker, err := NewCardReader(strings.NewReader("Xd")).Read()
+	if err != nil || joker != deck[53] || joker.String() != "X♦" {
+		t.Errorf("incorrect joker read - %s %v", joker, err)
+	}
+
+	dealer := NewDealer(DealScript{DealRound{DealStep{DealDown, 27}}}, 2, deck)
+	dealer.DealAll()
+	data, _ := dealer.MarshalBinary()
+	restored := &Dealer{}
+	if err := restored.UnmarshalBinary(data); err != nil || !reflect.DeepEqual(restored.Hand(1), dealer.Hand(1)) {
+		t.Errorf("joker deck snapshot - %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package odds
+
+import (
+	. "github.com/dohodges/fifty2"
+	"math/big"
+)
+
+type Probability struct {
+	rat *big.Rat
+}
+
+func (p Probability) Rat() *big.Rat {
+	return new(big.Rat).Set(p.rat)
+}
+
+func (p Probability) Float64() float64 {
+	f, _ := p.rat.Float64()
+	return f
+}
+
+func (p Probability) String() string {
+	return p.rat.RatString()
+}
+
+type Matcher func(Card) bool
+
+func RankIs(rank Rank) Matcher {
+	return func(c Card) bool { return c.Rank == rank }
+}
+
+func SuitIs(suit Suit) Matcher {
+	return func(c Card) bool { return c.Suit == suit }
+}
+
+func OneOf(cards ...Card) Matcher {
+	return func(c Card) bool { return Index(cards, c) >= 0 }
+}
+
+// Condition requires between Min and Max of the drawn cards to match. A
+// negative Max means no upper limit.
+type Condition struct {
+	Match Matcher
+	Min   int
+	Max   int
+}
+
+func AtLeast(match Matcher, k int) Condition {
+	return Condition{match, k, -1}
+}
+
+func AtMost(match Matcher, k int) Condition {
+	return Condition{match, 0, k}
+}
+
+func Exactly(match Matcher, k int) Condition {
+	return Condition{match, k, k}
+}
+
+func (c Condition) allows(n int) bool {
+	return n >= c.Min && (c.Max < 0 || n <= c.Max)
+}
+
+// Draw returns the exact probability that all conditions hold for draws
+// cards dealt from remaining.
+func Draw(remaining []Card, draws int, conditions ...Condition) Probability {
+	if draws < 0 || draws > len(remaining) {
+		panic("fifty2/odds: cannot draw more cards than remain")
+	}
+	if len(conditions) > 16 {
+		panic("fifty2/odds: too many conditions")
+	}
+
+	// partition the remaining cards by which conditions they match
+	cellSize := make(map[uint32]int)
+	for _, card := range remaining {
+		signature := uint32(0)
+		for i, cond := range conditions {
+			if cond.Match(card) {
+				signature |= 1 << uint(i)
+			}
+		}
+		cellSize[signature]++
+	}
+
+	cells := make([]uint32, 0, len(cellSize))
+	for signature := range cellSize {
+		cells = append(cells, signature)
+	}
+
+	favorable := new(big.Int)
+	counts := make([]int, len(cells))
+	var allocate func(cell, left int)
+	allocate = func(cell, left int) {
+		if cell == len(cells)-1 {
+			if left > cellSize[cells[cell]] {
+				return
+			}
+			counts[cell] = left
+			if satisfies(conditions, cells, counts) {
+				ways := big.NewInt(1)
+				for i, n := range counts {
+					ways.Mul(ways, new(big.Int).Binomial(int64(cellSize[cells[i]]), int64(n)))
+				}
+				favorable.Add(favorable, ways)
+			}
+			return
+		}
+		for n := 0; n <= left && n <= cellSize[cells[cell]]; n++ {
+			counts[cell] = n
+			allocate(cell+1, left-n)
+		}
+	}
+	if len(cells) > 0 {
+		allocate(0, draws)
+	} else if draws == 0 && satisfies(conditions, cells, counts) {
+		favorable.SetInt64(1)
+	}
+
+	total := new(big.Int).Binomial(int64(len(remaining)), int64(draws))
+	return Probability{new(big.Rat).SetFrac(favorable, total)}
+}
+
+func satisfies(conditions []Condition, cells []uint32, counts []int) bool {
+	for i, cond := range conditions {
+		matched := 0
+		for j, signature := range cells {
+			if signature&(1<<uint(i)) != 0 {
+				matched += counts[j]
+			}
+		}
+		if !cond.allows(matched) {
+			return false
+		}
+	}
+	return true
+}
+
+func AtLeastRank(remaining []Card, draws int, rank Rank, k int) Probability {
+	return Draw(remaining, draws, AtLeast(RankIs(rank), k))
+}
+
+func ExactlyRank(remaining []Card, draws int, rank Rank, k int) Probability {
+	return Draw(remaining, draws, Exactly(RankIs(rank), k))
+}
+
+func AtLeastSuit(remaining []Card, draws int, suit Suit, k int) Probability {
+	return Draw(remaining, draws, AtLeast(SuitIs(suit), k))
+}
+
+func ExactlySuit(remaining []Card, draws int, suit Suit, k int) Probability {
+	return Draw(remaining, draws, Exactly(SuitIs(suit), k))
+}
+
+// AnyOf returns the probability of drawing at least one of targets, e.g.
+// hitting one of a set of outs.
+func AnyOf(remaining []Card, draws int, targets ...Card) Probability {
+	return Draw(remaining, draws, AtLeast(OneOf(targets...), 1))
+}
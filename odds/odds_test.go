@@ -0,0 +1,53 @@
+package odds
+
+import (
+	. "github.com/dohodges/fifty2"
+	"math"
+	"testing"
+)
+
+func TestAtLeastRank(t *testing.T) {
+	assertProbability(t, AtLeastRank(NewDeck(), 3, Ace, 1), "1201/5525")
+	assertProbability(t, ExactlyRank(NewDeck(), 2, Ace, 2), "1/221")
+	assertProbability(t, AtLeastRank(NewDeck(), 5, Ace, 5), "0")
+}
+
+func TestSuit(t *testing.T) {
+	deck := Remove(NewDeck(), Card{Ace, Hearts}, Card{King, Hearts}, Card{Two, Hearts}, Card{Nine, Hearts}, Card{Jack, Clubs})
+	// flush draw on the flop, two cards to come
+	assertProbability(t, AtLeastSuit(deck, 2, Hearts, 1), "378/1081")
+	assertProbability(t, ExactlySuit(deck, 2, Hearts, 2), "36/1081")
+}
+
+func TestAnyOf(t *testing.T) {
+	deck := FromMask(Mask(NewDeck()) &^ Mask([]Card{Card{Four, Spades}, Card{Five, Hearts}, Card{Six, Diamonds}, Card{Seven, Clubs}}))
+	outs := []Card{Card{Three, Clubs}, Card{Three, Diamonds}, Card{Three, Hearts}, Card{Three, Spades},
+		Card{Eight, Clubs}, Card{Eight, Diamonds}, Card{Eight, Hearts}, Card{Eight, Spades}}
+	p := AnyOf(deck, 1, outs...)
+	assertProbability(t, p, "1/6")
+	if math.Abs(p.Float64()-1./6.) > 1e-12 {
+		t.Errorf("float %v != 1/6", p.Float64())
+	}
+}
+
+func TestJoint(t *testing.T) {
+	deck := NewDeck()
+	assertProbability(t, Draw(deck, 2, AtLeast(RankIs(Ace), 1), AtLeast(RankIs(King), 1)), "8/663")
+	// overlapping conditions - only the ace of spades satisfies both
+	assertProbability(t, Draw(deck, 1, AtLeast(RankIs(Ace), 1), AtLeast(SuitIs(Spades), 1)), "1/52")
+	assertProbability(t, Draw(deck, 5, AtMost(SuitIs(Spades), 0), AtMost(SuitIs(Hearts), 0), AtLeast(RankIs(Ace), 0)),
+		Draw(deck, 5, Exactly(func(c Card) bool { return c.Suit == Spades || c.Suit == Hearts }, 0)).String())
+	assertProbability(t, Draw(deck, 0), "1")
+	assertProbability(t, Draw(deck, 7), "1")
+}
+
+func TestMultipleDecks(t *testing.T) {
+	shoe := NewDeckSet(2)
+	assertProbability(t, Draw(shoe, 2, Exactly(OneOf(Card{Ace, Spades}), 2)), "1/5356")
+}
+
+func assertProbability(t *testing.T, actual Probability, expect string) {
+	if actual.String() != expect {
+		t.Errorf("probability %s != %s", actual, expect)
+	}
+}
@@ -0,0 +1,95 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"strings"
+)
+
+var BadugiLow Evaluator = BadugiEvaluator{}
+
+// BadugiEvaluator ranks badugi hands, the best set of up to four cards of
+// different suits and ranks with aces low. More cards beat fewer, then the
+// lowest cards win.
+//
+// A strength holds the number of cards in its top byte, where the other
+// evaluators keep the hand rank, followed by the ranks from highest to
+// lowest, one per nibble, counting the ace as 1.
+type BadugiEvaluator struct{}
+
+func (e BadugiEvaluator) Evaluate(hand []Card) HandStrength {
+	_, strength := e.BestFive(hand)
+	return strength
+}
+
+// BestFive returns the cards of the best badugi in hand, which is never more
+// than four cards.
+func (e BadugiEvaluator) BestFive(hand []Card) ([]Card, HandStrength) {
+	var best []Card
+	bestStrength := MakeHandStrength(NoHand, 0, 0, 0)
+	for n := 4; n > 0 && best == nil; n-- {
+		if n > len(hand) {
+			continue
+		}
+		for itr := Combinations(hand, n); itr.HasNext(); {
+			cards := itr.Next()
+			if strength, ok := getBadugiStrength(cards); ok && (best == nil || e.Compare(strength, bestStrength) > 0) {
+				best, bestStrength = cards, strength
+			}
+		}
+	}
+	return best, bestStrength
+}
+
+func getBadugiStrength(cards []Card) (HandStrength, bool) {
+	var ranks uint16
+	var suits uint8
+	for _, card := range cards {
+		if card.Rank == Joker || ranks&card.Rank.Mask() != 0 || suits&card.Suit.Mask() != 0 {
+			return 0, false
+		}
+		ranks |= card.Rank.Mask()
+		suits |= card.Suit.Mask()
+	}
+
+	strength := HandStrength(len(cards)) << 24
+	shift := uint(12)
+	for r := King + 1; r > Ace; r-- {
+		if ranks&(r-1).Mask() != 0 {
+			strength |= HandStrength(r) << shift
+			shift -= 4
+		}
+	}
+	return strength, true
+}
+
+func (BadugiEvaluator) Compare(a, b HandStrength) int {
+	if a.Rank() != b.Rank() {
+		return compareHigh(HandStrength(a.Rank()), HandStrength(b.Rank()))
+	}
+	return compareLow(a, b)
+}
+
+func (BadugiEvaluator) Describe(strength HandStrength) string {
+	return DescribeBadugi(strength)
+}
+
+// DescribeBadugi describes a strength from the badugi evaluator, such as
+// "Badugi, Seven-Five-Three-Ace" or "Three Card, Six-Four-Two".
+func DescribeBadugi(hs HandStrength) string {
+	names := make([]string, hs.Rank())
+	for i := range names {
+		names[i] = CardStrength(hs>>uint(12-4*i)&0xF - 1).Name()
+	}
+
+	switch len(names) {
+	case 4:
+		return "Badugi, " + strings.Join(names, "-")
+	case 3:
+		return "Three Card, " + strings.Join(names, "-")
+	case 2:
+		return "Two Card, " + strings.Join(names, "-")
+	case 1:
+		return "One Card, " + names[0]
+	}
+	return "No Hand"
+}
@@ -0,0 +1,77 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"testing"
+)
+
+func TestBadugiHands(t *testing.T) {
+	counts := make(map[HandRank]int)
+	for itr := Combinations(NewDeck(), 4); itr.HasNext(); {
+		counts[BadugiLow.Evaluate(itr.Next()).Rank()]++
+	}
+	if counts[4] != 17160 {
+		t.Errorf("%d four card badugis, expected 17160", counts[4])
+	}
+	if counts[0] != 0 {
+		t.Errorf("%d hands without a badugi", counts[0])
+	}
+}
+
+func TestBadugi(t *testing.T) {
+	order := []string{
+		"Ac2d3h4s",
+		"Ac2d3h5s",
+		"Ac2d4h5s",
+		"Kc2d3h4s",
+		"Ac2d3hKh",
+		"Ac2d4h4s",
+		"Ac2c3h4h",
+		"Ac2c3c4c",
+	}
+
+	strengths := make([]HandStrength, len(order))
+	for i, s := range order {
+		strengths[i] = BadugiLow.Evaluate(readCards(t, s))
+	}
+	for i := 1; i < len(strengths); i++ {
+		if BadugiLow.Compare(strengths[i-1], strengths[i]) <= 0 || BadugiLow.Compare(strengths[i], strengths[i-1]) >= 0 {
+			t.Errorf("%s does not beat %s", order[i-1], order[i])
+		}
+	}
+
+	cards, strength := BadugiLow.BestFive(readCards(t, "Ac2c3h9d7s"))
+	assertCards(t, cards, readCards(t, "Ac3h9d7s"))
+	if strength != BadugiLow.Evaluate(readCards(t, "Ac3h7s9d")) {
+		t.Errorf("badugi strength %#X", strength)
+	}
+
+	if best := Best(BadugiLow, strengths[2:5]); len(best) != 1 || best[0] != 0 {
+		t.Errorf("best badugi hands %v", best)
+	}
+}
+
+func TestDescribeBadugi(t *testing.T) {
+	assertDescription(t, BadugiLow, "7s5d3hAc", "Badugi, Seven-Five-Three-Ace")
+	assertDescription(t, BadugiLow, "6s4d2hKh", "Three Card, Six-Four-Two")
+	assertDescription(t, BadugiLow, "KsKdKhAs", "Two Card, King-Ace")
+	assertDescription(t, BadugiLow, "2s3s4s5s", "One Card, Two")
+}
+
+func TestBadugiGames(t *testing.T) {
+	if game := GetGame(Badugi); game.HandSize != 4 || game.Lo != BadugiLow || game.HasHiHand() {
+		t.Errorf("badugi %+v", game)
+	}
+
+	game := GetGame(Badeucy)
+	hand := readCards(t, "7s5d3hAc2c")
+	if strength := game.HiStrength(nil, hand); strength != BadugiLow.Evaluate(readCards(t, "7s5d3hAc")) {
+		t.Errorf("badeucy badugi %s", DescribeBadugi(strength))
+	}
+	if strength := game.LoStrength(nil, hand); strength.Rank() != HighCard {
+		t.Errorf("badeucy 2-7 %s", DescribeDeuceToSeven(strength))
+	}
+	if strength := GetGame(Badacey).LoStrength(nil, hand); strength != AceToFiveLow.Evaluate(hand) {
+		t.Errorf("badacey low %s", DescribeLow(strength))
+	}
+}
@@ -0,0 +1,110 @@
+package poker
+
+import (
+	"github.com/golang/groupcache/lru"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	DefaultCacheSize = 2598960
+	cacheShards      = 64
+	// approximate memory used by one cached hand strength, including the
+	// lru list element and map entry
+	cacheEntryBytes = 128
+)
+
+type cacheShard struct {
+	sync.Mutex
+	lru *lru.Cache
+}
+
+type handCache struct {
+	shards [cacheShards]cacheShard
+}
+
+func newHandCache(entries int) *handCache {
+	hc := &handCache{}
+	perShard := (entries + cacheShards - 1) / cacheShards
+	for i := range hc.shards {
+		hc.shards[i].lru = lru.New(perShard)
+	}
+	return hc
+}
+
+func (hc *handCache) shard(mask uint64) *cacheShard {
+	mask ^= mask >> 29
+	mask *= 0xBF58476D1CE4E5B9
+	mask ^= mask >> 32
+	return &hc.shards[mask%cacheShards]
+}
+
+func (hc *handCache) get(mask uint64) (HandStrength, bool) {
+	shard := hc.shard(mask)
+	shard.Lock()
+	strength, hit := shard.lru.Get(mask)
+	shard.Unlock()
+	if !hit {
+		return 0, false
+	}
+	return strength.(HandStrength), true
+}
+
+func (hc *handCache) add(mask uint64, strength HandStrength) {
+	shard := hc.shard(mask)
+	shard.Lock()
+	shard.lru.Add(mask, strength)
+	shard.Unlock()
+}
+
+var (
+	cacheMutex sync.Mutex
+	cacheSize  = DefaultCacheSize
+	cache      atomic.Value // *cacheState, allocated on first use
+)
+
+type cacheState struct {
+	hc *handCache
+}
+
+// SetCacheSize sets the number of hand strengths GetHandStrength caches. A
+// size of 0 disables the cache. Changing the size discards the cache.
+func SetCacheSize(entries int) {
+	if entries < 0 {
+		entries = 0
+	}
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	cacheSize = entries
+	cache.Store((*cacheState)(nil))
+}
+
+// SetCacheMemory sizes the cache to use about the given number of bytes.
+func SetCacheMemory(bytes int) {
+	SetCacheSize(bytes / cacheEntryBytes)
+}
+
+func CacheSize() int {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	return cacheSize
+}
+
+// getCache returns nil when caching is disabled.
+func getCache() *handCache {
+	if state, _ := cache.Load().(*cacheState); state != nil {
+		return state.hc
+	}
+
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	if state, _ := cache.Load().(*cacheState); state != nil {
+		return state.hc
+	}
+	state := &cacheState{}
+	if cacheSize > 0 {
+		state.hc = newHandCache(cacheSize)
+	}
+	cache.Store(state)
+	return state.hc
+}
@@ -0,0 +1,271 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (cs CardStrength) Name() string {
+	switch cs.Rank() {
+	case 0:
+		return "Ace"
+	case 1:
+		return "Two"
+	case 2:
+		return "Three"
+	case 3:
+		return "Four"
+	case 4:
+		return "Five"
+	case 5:
+		return "Six"
+	case 6:
+		return "Seven"
+	case 7:
+		return "Eight"
+	case 8:
+		return "Nine"
+	case 9:
+		return "Ten"
+	case 10:
+		return "Jack"
+	case 11:
+		return "Queen"
+	case 12:
+		return "King"
+	}
+	return ""
+}
+
+func (cs CardStrength) Plural() string {
+	if cs.Rank() == 5 {
+		return "Sixes"
+	}
+	return cs.Name() + "s"
+}
+
+func (cs CardStrength) Rune() rune {
+	return cs.Rank().Rune()
+}
+
+func (hs HandStrength) strength1() CardStrength {
+	return CardStrength(hs>>20) & 0xF
+}
+
+func (hs HandStrength) strength2() CardStrength {
+	return CardStrength(hs>>16) & 0xF
+}
+
+func (hs HandStrength) kickers() uint16 {
+	return uint16(hs)
+}
+
+// kickerStrengths returns the kickers from highest to lowest.
+func kickerStrengths(kickers uint16) []CardStrength {
+	strengths := make([]CardStrength, 0, 5)
+	for cs := AceHigh + 1; cs > AceLow; cs-- {
+		if kickers&(cs-1).Mask() != 0 {
+			strengths = append(strengths, cs-1)
+		}
+	}
+	return strengths
+}
+
+func joinNames(strengths []CardStrength) string {
+	names := make([]string, len(strengths))
+	for i, cs := range strengths {
+		names[i] = cs.Name()
+	}
+	return strings.Join(names, "-")
+}
+
+func withKickers(description string, kickers uint16) string {
+	strengths := kickerStrengths(kickers)
+	switch len(strengths) {
+	case 0:
+		return description
+	case 1:
+		return fmt.Sprintf("%s with %s %s kicker", description, article(strengths[0]), strengths[0].Name())
+	}
+	return fmt.Sprintf("%s with %s", description, joinNames(strengths))
+}
+
+func article(cs CardStrength) string {
+	if cs.Rank() == 0 || cs.Rank() == 7 {
+		return "an"
+	}
+	return "a"
+}
+
+// DescribeHigh describes a strength from GetHandStrength, such as "Full
+// House, Kings full of Sevens".
+func DescribeHigh(hs HandStrength) string {
+	switch hs.Rank() {
+	case FiveOfAKind:
+		return "Five of a Kind, " + hs.strength1().Plural()
+	case StraightFlush:
+		if hs.strength1() == AceHigh {
+			return "Royal Flush"
+		}
+		return fmt.Sprintf("Straight Flush, %s high", hs.strength1().Name())
+	case Quads:
+		return withKickers("Four of a Kind, "+hs.strength1().Plural(), hs.kickers())
+	case FullHouse:
+		return fmt.Sprintf("Full House, %s full of %s", hs.strength1().Plural(), hs.strength2().Plural())
+	case Flush:
+		return fmt.Sprintf("Flush, %s high", kickerStrengths(hs.kickers())[0].Name())
+	case Straight:
+		return fmt.Sprintf("Straight, %s high", hs.strength1().Name())
+	case Trips:
+		return withKickers("Three of a Kind, "+hs.strength1().Plural(), hs.kickers())
+	case TwoPair:
+		return withKickers(fmt.Sprintf("Two Pair, %s and %s", hs.strength1().Plural(), hs.strength2().Plural()), hs.kickers())
+	case Pair:
+		return withKickers("Pair of "+hs.strength1().Plural(), hs.kickers())
+	case HighCard:
+		strengths := kickerStrengths(hs.kickers())
+		if len(strengths) == 0 {
+			return "No Hand"
+		}
+		return withKickers(strengths[0].Name()+" High", hs.kickers()&^strengths[0].Mask())
+	}
+	return "No Hand"
+}
+
+const wheel = 0x001F
+
+// DescribeLow describes a strength from GetLowHandStrength, such as
+// "Seven-Five low" or "Wheel".
+func DescribeLow(hs HandStrength) string {
+	switch hs.Rank() {
+	case NoHand:
+		return "No Low"
+	case HighCard:
+		if hs.kickers() == wheel {
+			return "Wheel"
+		}
+		strengths := kickerStrengths(hs.kickers())
+		if len(strengths) == 1 {
+			return strengths[0].Name() + " low"
+		}
+		return fmt.Sprintf("%s-%s low", strengths[0].Name(), strengths[1].Name())
+	}
+	return DescribeHigh(hs)
+}
+
+// DescribeDeuceToSeven describes a deuce-to-seven lowball strength, such as
+// "Eight-Six low" or "Number One" for 7-5-4-3-2.
+func DescribeDeuceToSeven(hs HandStrength) string {
+	if hs.Rank() != HighCard {
+		return DescribeHigh(hs)
+	}
+	if hs.kickers() == 0x005E {
+		return "Number One"
+	}
+	strengths := kickerStrengths(hs.kickers())
+	if len(strengths) == 1 {
+		return strengths[0].Name() + " low"
+	}
+	return fmt.Sprintf("%s-%s low", strengths[0].Name(), strengths[1].Name())
+}
+
+// Explainer is implemented by evaluators that can explain why one hand
+// beats another.
+type Explainer interface {
+	Explain(a, b HandStrength) string
+}
+
+// Explain explains how a fares against b, such as "wins with a better
+// kicker (Q vs J)".
+func Explain(e Evaluator, a, b HandStrength) string {
+	if explainer, ok := e.(Explainer); ok {
+		return explainer.Explain(a, b)
+	}
+	switch c := e.Compare(a, b); {
+	case c > 0:
+		return fmt.Sprintf("wins with %s over %s", e.Describe(a), e.Describe(b))
+	case c < 0:
+		return fmt.Sprintf("loses to %s", e.Describe(b))
+	}
+	return "ties"
+}
+
+func (e HighEvaluator) Explain(a, b HandStrength) string {
+	return explain(e, a, b, false)
+}
+
+func (e LowEvaluator) Explain(a, b HandStrength) string {
+	return explain(e, a, b, true)
+}
+
+func (e DeuceToSevenEvaluator) Explain(a, b HandStrength) string {
+	return explain(e, a, b, true)
+}
+
+func explain(e Evaluator, a, b HandStrength, low bool) string {
+	c := e.Compare(a, b)
+	if c == 0 {
+		return "ties"
+	}
+	winner, loser, verb := a, b, "wins with"
+	if c < 0 {
+		winner, loser, verb = b, a, "loses to"
+	}
+
+	describe := func() string {
+		if c > 0 {
+			return fmt.Sprintf("wins with %s over %s", e.Describe(winner), e.Describe(loser))
+		}
+		return fmt.Sprintf("loses to %s", e.Describe(winner))
+	}
+	if winner.Rank() != loser.Rank() {
+		return describe()
+	}
+
+	better := "higher"
+	if low {
+		better = "lower"
+	}
+	versus := func(what string, w, l CardStrength) string {
+		return fmt.Sprintf("%s a %s (%c vs %c)", verb, what, w.Rune(), l.Rune())
+	}
+
+	if winner.strength1() != loser.strength1() {
+		switch winner.Rank() {
+		case FiveOfAKind:
+			return versus(better+" five of a kind", winner.strength1(), loser.strength1())
+		case StraightFlush:
+			return versus(better+" straight flush", winner.strength1(), loser.strength1())
+		case Quads:
+			return versus(better+" four of a kind", winner.strength1(), loser.strength1())
+		case FullHouse, Trips:
+			return versus(better+" three of a kind", winner.strength1(), loser.strength1())
+		case Straight:
+			return versus(better+" straight", winner.strength1(), loser.strength1())
+		case TwoPair:
+			return versus(better+" top pair", winner.strength1(), loser.strength1())
+		case Pair:
+			return versus(better+" pair", winner.strength1(), loser.strength1())
+		}
+	}
+	if winner.strength2() != loser.strength2() {
+		what := better + " pair"
+		if winner.Rank() == TwoPair {
+			what = better + " second pair"
+		}
+		return versus(what, winner.strength2(), loser.strength2())
+	}
+
+	w := kickerStrengths(winner.kickers())
+	l := kickerStrengths(loser.kickers())
+	for i := 0; i < len(w) && i < len(l); i++ {
+		if w[i] != l[i] {
+			what := "better kicker"
+			if winner.Rank() == Flush || winner.Rank() == HighCard {
+				what = better + " card"
+			}
+			return versus(what, w[i], l[i])
+		}
+	}
+	return describe()
+}
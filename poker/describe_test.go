@@ -0,0 +1,62 @@
+package poker
+
+import (
+	"testing"
+)
+
+func TestDescribeHigh(t *testing.T) {
+	assertDescription(t, High, "AsKsQsJsTs", "Royal Flush")
+	assertDescription(t, High, "As2s3s4s5s", "Straight Flush, Five high")
+	assertDescription(t, High, "7cKhKcKdKs", "Four of a Kind, Kings with a Seven kicker")
+	assertDescription(t, High, "KcKd7sKs7c", "Full House, Kings full of Sevens")
+	assertDescription(t, High, "2h3hTh4hJh", "Flush, Jack high")
+	assertDescription(t, High, "8h9sJhTs7d", "Straight, Jack high")
+	assertDescription(t, High, "Ah2s3d4c5h", "Straight, Five high")
+	assertDescription(t, High, "9h8s9s4c9c", "Three of a Kind, Nines with Eight-Four")
+	assertDescription(t, High, "AhAs4c4dQh", "Two Pair, Aces and Fours with a Queen kicker")
+	assertDescription(t, High, "6h6sAc4dQh", "Pair of Sixes with Ace-Queen-Four")
+	assertDescription(t, High, "7sQd2hAs3c", "Ace High with Queen-Seven-Three-Two")
+	assertDescription(t, High, "9s9d", "Pair of Nines")
+}
+
+func TestDescribeLow(t *testing.T) {
+	assertDescription(t, AceToFiveLow, "As2s3s4s5s", "Wheel")
+	assertDescription(t, AceToFiveLow, "7s5d3hAs2c", "Seven-Five low")
+	assertDescription(t, AceToFiveLow, "8s7d3hAs2c", "Eight-Seven low")
+	assertDescription(t, AceToFiveLow, "AhAs4c4dQh", "Two Pair, Fours and Aces with a Queen kicker")
+	assertDescription(t, AceToFiveLow8, "Ts7d3hAs2c", "No Low")
+	assertDescription(t, AceToFiveLow, "8s", "Eight low")
+}
+
+func TestDescribeDeuceToSeven(t *testing.T) {
+	assertDescription(t, DeuceToSeven, "7s5d4h3c2s", "Number One")
+	assertDescription(t, DeuceToSeven, "8s6d4h3c2s", "Eight-Six low")
+	assertDescription(t, DeuceToSeven, "As5d4h3c2s", "Ace-Five low")
+	assertDescription(t, DeuceToSeven, "7s6d5h4c3s", "Straight, Seven high")
+	assertExplanation(t, DeuceToSeven, "8s6d4h3c2s", "8h6c5h3d2d", "wins with a lower card (4 vs 5)")
+	assertExplanation(t, DeuceToSeven, "As5d4h3c2s", "KsQdJhTc8s", "loses to a lower card (K vs A)")
+}
+
+func TestExplain(t *testing.T) {
+	assertExplanation(t, High, "AhAs4c4dQh", "AcAd4h4sJh", "wins with a better kicker (Q vs J)")
+	assertExplanation(t, High, "AcAd4h4sJh", "AhAs4c4dQh", "loses to a better kicker (Q vs J)")
+	assertExplanation(t, High, "AhAs4c4dQh", "AcAd5h5sJh", "loses to a higher second pair (5 vs 4)")
+	assertExplanation(t, High, "KcKd7sKs7c", "2h3hTh4hJh", "wins with Full House, Kings full of Sevens over Flush, Jack high")
+	assertExplanation(t, High, "8h9sJhTs7d", "8c9dJsTd7h", "ties")
+	assertExplanation(t, High, "2h3hTh4hJh", "2c3cTc5cJc", "loses to a higher card (5 vs 4)")
+	assertExplanation(t, AceToFiveLow, "7s5d3hAs2c", "7h6d3cAd2d", "wins with a lower card (5 vs 6)")
+	assertExplanation(t, AceToFiveLow8, "Ts7d3hAs2c", "7h6d3cAd2d", "loses to Seven-Six low")
+	assertExplanation(t, AceToFiveLow, "7s7d3hAs2c", "7h7c4cAd2d", "wins with a better kicker (3 vs 4)")
+}
+
+func assertDescription(t *testing.T, e Evaluator, hand string, expect string) {
+	if description := e.Describe(e.Evaluate(readCards(t, hand))); description != expect {
+		t.Errorf("%s described as %q, expected %q", hand, description, expect)
+	}
+}
+
+func assertExplanation(t *testing.T, e Evaluator, a, b string, expect string) {
+	if explanation := Explain(e, e.Evaluate(readCards(t, a)), e.Evaluate(readCards(t, b))); explanation != expect {
+		t.Errorf("%s vs %s explained as %q, expected %q", a, b, explanation, expect)
+	}
+}
@@ -0,0 +1,79 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"sort"
+)
+
+// DrawAnalysis is every outcome of keeping some cards and drawing the rest.
+type DrawAnalysis struct {
+	Keep    []Card
+	Discard []Card
+	// Outcomes counts the draws that make each strength.
+	Outcomes map[HandStrength]int64
+	Draws    int64
+	// EV is the average value of the hands made.
+	EV float64
+}
+
+// Probability returns the chance of making a strength that matches.
+func (da DrawAnalysis) Probability(match func(HandStrength) bool) float64 {
+	count := int64(0)
+	for strength, n := range da.Outcomes {
+		if match(strength) {
+			count += n
+		}
+	}
+	return float64(count) / float64(da.Draws)
+}
+
+// RankProbabilities returns the chance of making each hand rank.
+func (da DrawAnalysis) RankProbabilities() map[HandRank]float64 {
+	probabilities := make(map[HandRank]float64)
+	for strength, n := range da.Outcomes {
+		probabilities[strength.Rank()] += float64(n) / float64(da.Draws)
+	}
+	return probabilities
+}
+
+// AnalyzeDraw finds the exact outcomes of keeping keep and drawing from deck
+// up to size cards, according to e. value gives the worth of each strength
+// made, such as 1 for a winning hand, for the EV.
+func AnalyzeDraw(e Evaluator, keep, deck []Card, size int, value func(HandStrength) float64) DrawAnalysis {
+	da := DrawAnalysis{
+		Keep:     append([]Card{}, keep...),
+		Outcomes: make(map[HandStrength]int64),
+	}
+
+	hand := make([]Card, size)
+	copy(hand, keep)
+	for itr := Combinations(deck, size-len(keep)); itr.HasNext(); {
+		copy(hand[len(keep):], itr.Next())
+		da.Outcomes[e.Evaluate(hand)]++
+		da.Draws++
+	}
+
+	total := 0.
+	for strength, n := range da.Outcomes {
+		total += value(strength) * float64(n)
+	}
+	da.EV = total / float64(da.Draws)
+	return da
+}
+
+// AnalyzeDraws analyzes every way to draw to hand, from keeping every card
+// to drawing five, best EV first. deck is the cards that can be drawn, the
+// game's deck without hand and any dead or known cards.
+func AnalyzeDraws(e Evaluator, hand, deck []Card, value func(HandStrength) float64) []DrawAnalysis {
+	analyses := make([]DrawAnalysis, 0, 1<<uint(len(hand)))
+	for n := len(hand); n >= 0; n-- {
+		for itr := Combinations(hand, n); itr.HasNext(); {
+			keep := itr.Next()
+			da := AnalyzeDraw(e, keep, deck, len(hand), value)
+			da.Discard = Remove(append([]Card{}, hand...), keep...)
+			analyses = append(analyses, da)
+		}
+	}
+	sort.SliceStable(analyses, func(i, j int) bool { return analyses[i].EV > analyses[j].EV })
+	return analyses
+}
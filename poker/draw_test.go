@@ -0,0 +1,145 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"math"
+	"testing"
+)
+
+// jacksOrBetter is the 9/6 jacks or better video poker pay table.
+func jacksOrBetter(strength HandStrength) float64 {
+	switch strength.Rank() {
+	case StraightFlush:
+		if strength.strength1() == AceHigh {
+			return 800
+		}
+		return 50
+	case Quads:
+		return 25
+	case FullHouse:
+		return 9
+	case Flush:
+		return 6
+	case Straight:
+		return 4
+	case Trips:
+		return 3
+	case TwoPair:
+		return 2
+	case Pair:
+		if strength.strength1() >= CardStrength(Jack) {
+			return 1
+		}
+	}
+	return 0
+}
+
+func TestOpeners(t *testing.T) {
+	draw := GetGame(FiveCardDraw)
+	for _, test := range []struct {
+		hand string
+		open bool
+	}{
+		{"JsJd7c4h2s", true},
+		{"AsAdKcKh2s", true},
+		{"5s6d7c8h9s", true},
+		{"TsTdAcKh2s", false},
+		{"AsKdQcJh9s", false},
+	} {
+		if open := draw.CanOpen(readCards(t, test.hand)); open != test.open {
+			t.Errorf("%s can open %v, expected %v", test.hand, open, test.open)
+		}
+	}
+	if !GetGame(CaliforniaLowball).CanOpen(readCards(t, "KsKdQcQh2s")) {
+		t.Errorf("lowball needs openers")
+	}
+}
+
+func TestJokerLow(t *testing.T) {
+	for _, test := range []struct {
+		hand        string
+		description string
+	}{
+		{"Xc2s3d4h5c", "Wheel"},
+		{"XcAs2d3h4c", "Wheel"},
+		{"Xc2s3d4h8c", "Eight-Four low"},
+		{"XcKsKdQhJc", "Pair of Kings with Queen-Jack-Ace"},
+		{"Xc7s7d4h2c", "Pair of Sevens with Four-Two-Ace"},
+	} {
+		assertDescription(t, JokerLow, test.hand, test.description)
+	}
+
+	// the joker is as good as the best card it could be
+	source := NewSource(48)
+	deck := NewJokerDeck(1)
+	for i := 0; i < 2000; i++ {
+		hand := SampleWith(deck, 5+i%3, source)
+		best := MakeHandStrength(NoHand, 0, 0, 0)
+		for _, card := range NewDeck() {
+			sub := append([]Card{}, hand...)
+			if index := Index(sub, Card{Joker, Clubs}); index >= 0 {
+				sub[index] = card
+			}
+			if strength := AceToFiveLow.Evaluate(sub); AceToFiveLow.Compare(strength, best) > 0 {
+				best = strength
+			}
+		}
+		if strength := JokerLow.Evaluate(hand); strength != best {
+			t.Fatalf("%v is %s, expected %s", hand, DescribeLow(strength), DescribeLow(best))
+		}
+	}
+}
+
+func TestAnalyzeDraw(t *testing.T) {
+	hand := readCards(t, "AhKh7h2h9s")
+	deck := Remove(NewDeck(), hand...)
+
+	flush := func(strength HandStrength) float64 {
+		if strength.Rank() >= Flush {
+			return 1
+		}
+		return 0
+	}
+	da := AnalyzeDraw(High, hand[:4], deck, 5, flush)
+	if da.Draws != 47 || math.Abs(da.EV-9./47) > 1e-9 || math.Abs(da.RankProbabilities()[Flush]-9./47) > 1e-9 {
+		t.Errorf("flush draw %d draws, EV %f, expected 47 draws, EV %f", da.Draws, da.EV, 9./47)
+	}
+	pairs := da.Probability(func(strength HandStrength) bool { return strength.Rank() == Pair })
+	if math.Abs(pairs-12./47) > 1e-9 {
+		t.Errorf("flush draw pairs %f, expected %f", pairs, 12./47)
+	}
+
+	// California lowball, any ace or five makes a wheel
+	hand = readCards(t, "Xc2s3d4hKc")
+	deck = Remove(NewJokerDeck(1), hand...)
+	da = AnalyzeDraw(JokerLow, hand[:4], deck, 5, func(HandStrength) float64 { return 0 })
+	if wheels := da.Probability(func(strength HandStrength) bool { return DescribeLow(strength) == "Wheel" }); math.Abs(wheels-8./48) > 1e-9 {
+		t.Errorf("wheel draw %f, expected %f", wheels, 8./48)
+	}
+}
+
+func TestAnalyzeDraws(t *testing.T) {
+	hand := readCards(t, "AhKhQhJh9s")
+	analyses := AnalyzeDraws(High, hand, Remove(NewDeck(), hand...), jacksOrBetter)
+	if len(analyses) != 32 {
+		t.Fatalf("%d ways to draw, expected 32", len(analyses))
+	}
+	// a royal, 8 flushes, 3 straights and 12 high pairs
+	best := analyses[0]
+	if len(best.Discard) != 1 || best.Discard[0] != hand[4] || math.Abs(best.EV-872./47) > 1e-9 {
+		t.Errorf("best draw discards %v with EV %f, expected 9♠ with EV %f", best.Discard, best.EV, 872./47)
+	}
+	for _, da := range analyses {
+		if da.Draws != combination(47, len(da.Discard)) {
+			t.Errorf("discarding %v draws %d hands", da.Discard, da.Draws)
+		}
+	}
+}
+
+func combination(n, k int) int64 {
+	c := int64(1)
+	for i := 0; i < k; i++ {
+		c = c * int64(n-i) / int64(i+1)
+	}
+	return c
+}
@@ -0,0 +1,295 @@
+// Package equity calculates each player's share of a pot when their hands
+// are known only as weighted ranges.
+package equity
+
+import (
+	"fmt"
+	. "github.com/dohodges/fifty2"
+	. "github.com/dohodges/fifty2/poker"
+	"math/rand"
+	"sort"
+)
+
+// Spot is a pot being contested by ranges. Board is the known board cards
+// in the order they are dealt, all boards joined in multi-board games.
+type Spot struct {
+	Game   Game
+	Board  []Card
+	Dead   []Card
+	Ranges []Range
+}
+
+// Result is one player's share of the pot. Equity is the expected share,
+// counting ties and split pots. Scoop is the chance of winning the whole pot
+// alone. The Hi and Lo chances are for each board, averaged over the boards.
+type Result struct {
+	Equity float64
+	Scoop  float64
+	HiWin  float64
+	HiTie  float64
+	LoWin  float64
+	LoTie  float64
+}
+
+// Exact enumerates every holding in the ranges that doesn't conflict with
+// the known cards or the other holdings, and every runout of each. Holdings
+// count in proportion to the product of their weights.
+func Exact(spot Spot) ([]Result, error) {
+	s, err := newShowdown(spot)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, len(spot.Ranges))
+	total := 0.
+	holdings := make([]Combo, len(s.ranges))
+	var enumerate func(player int, mask uint64, weight float64)
+	enumerate = func(player int, mask uint64, weight float64) {
+		if player == len(s.ranges) {
+			runouts := make([]Result, len(results))
+			count := 0.
+			deck := s.deck(mask)
+			for itr := Combinations(deck, s.dealt(holdings)); itr.HasNext(); {
+				for split := MultipleCombinations(itr.Next(), s.choose); split.HasNext(); {
+					s.tally(runouts, split.Next(), 1)
+					count++
+				}
+			}
+			add(results, runouts, weight/count)
+			total += weight
+			return
+		}
+		for _, combo := range s.ranges[player].Range {
+			if cards := Mask(combo.Cards); cards&mask == 0 {
+				holdings[player] = combo
+				enumerate(player+1, mask|cards, weight*combo.Weight)
+			}
+		}
+	}
+	enumerate(0, s.known, 1)
+
+	if total == 0 {
+		return nil, fmt.Errorf("fifty2/poker/equity: every holding conflicts with another")
+	}
+	scale(results, 1/total)
+	return results, nil
+}
+
+// MonteCarlo samples iterations holdings and runouts. Each sample is drawn
+// from its own stream of source, so any sample can be replayed alone.
+func MonteCarlo(spot Spot, iterations int, source *Source) ([]Result, error) {
+	if iterations < 1 {
+		return nil, fmt.Errorf("fifty2/poker/equity: %d iterations", iterations)
+	}
+	s, err := newShowdown(spot)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, len(spot.Ranges))
+	holdings := make([]Combo, len(s.ranges))
+	for i := 0; i < iterations; i++ {
+		stream := source.Stream(uint64(i))
+		r := rand.New(stream)
+		var mask uint64
+		for ok, tries := false, 0; !ok; {
+			if tries++; tries > maxTries {
+				return nil, fmt.Errorf("fifty2/poker/equity: no holdings found without conflicts in %d tries", maxTries)
+			}
+			mask, ok = s.known, true
+			for player := range s.ranges {
+				holdings[player] = s.ranges[player].sample(r)
+				cards := Mask(holdings[player].Cards)
+				if cards&mask != 0 {
+					ok = false
+					break
+				}
+				mask |= cards
+			}
+		}
+
+		deal := SampleWith(s.deck(mask), s.dealt(holdings), stream)
+		split := make([][]Card, len(s.choose))
+		for j, n := range s.choose {
+			split[j], deal = deal[:n], deal[n:]
+		}
+		s.tally(results, split, 1)
+	}
+
+	scale(results, 1/float64(iterations))
+	return results, nil
+}
+
+// maxTries is the number of times MonteCarlo samples the ranges for
+// holdings that don't conflict before giving up.
+const maxTries = 10000
+
+// showdown deals out and decides a spot for holdings of the ranges.
+type showdown struct {
+	game   Game
+	boards [][]Card
+	ranges []weightedRange
+	known  uint64
+	choose []int
+	full   [][]Card
+}
+
+func newShowdown(spot Spot) (*showdown, error) {
+	if len(spot.Ranges) < 2 {
+		return nil, fmt.Errorf("fifty2/poker/equity: %d ranges, expected at least 2", len(spot.Ranges))
+	}
+	if err := spot.Game.Validate(spot.Board); err != nil {
+		return nil, err
+	}
+	known := Mask(spot.Board)
+	for _, card := range spot.Dead {
+		if known&card.Mask() != 0 {
+			return nil, fmt.Errorf("fifty2/poker/equity: dead card %s is dealt more than once", card)
+		}
+		known |= card.Mask()
+	}
+
+	s := &showdown{
+		game:   spot.Game,
+		boards: spot.Game.SplitBoard(spot.Board),
+		ranges: make([]weightedRange, len(spot.Ranges)),
+		known:  known,
+	}
+	for i, r := range spot.Ranges {
+		// holdings with known cards can't be dealt, the rest must be hands
+		// of the game
+		r = r.Without(spot.Board...).Without(spot.Dead...)
+		if len(r) == 0 {
+			return nil, fmt.Errorf("fifty2/poker/equity: player %d has no holdings without the known cards", i+1)
+		}
+		for _, combo := range r {
+			if err := spot.Game.Validate(spot.Board, combo.Cards); err != nil {
+				return nil, fmt.Errorf("fifty2/poker/equity: player %d %v", i+1, err)
+			}
+		}
+		s.ranges[i] = newWeightedRange(r)
+	}
+	return s, nil
+}
+
+// deck returns the cards that can still be dealt, without those in mask.
+func (s *showdown) deck(mask uint64) []Card {
+	deck := make([]Card, 0, 52)
+	for _, card := range s.game.NewDeck() {
+		if mask&card.Mask() == 0 {
+			deck = append(deck, card)
+		}
+	}
+	return deck
+}
+
+// dealt sets up the boards and hands for holdings and returns the number of
+// cards to deal to complete them.
+func (s *showdown) dealt(holdings []Combo) int {
+	s.choose = s.choose[:0]
+	s.full = s.full[:0]
+	cards := 0
+	for _, board := range s.boards {
+		full := make([]Card, s.game.BoardSize)
+		copy(full, board)
+		s.full = append(s.full, full)
+		s.choose = append(s.choose, s.game.BoardSize-len(board))
+		cards += s.game.BoardSize - len(board)
+	}
+	for _, combo := range holdings {
+		full := make([]Card, s.handSize(combo.Cards))
+		copy(full, combo.Cards)
+		s.full = append(s.full, full)
+		s.choose = append(s.choose, len(full)-len(combo.Cards))
+		cards += len(full) - len(combo.Cards)
+	}
+	return cards
+}
+
+// handSize returns the number of cards hand is dealt. Hands of only their
+// kept cards once it's time to discard have already discarded.
+func (s *showdown) handSize(hand []Card) int {
+	g := s.game
+	if g.Discards > 0 && len(s.boards[0])*len(s.boards) >= g.DiscardAt && len(hand) <= g.KeptCards() {
+		return g.KeptCards()
+	}
+	return g.HandSize
+}
+
+// tally adds the showdown of one runout, split like s.choose, to results
+// with weight.
+func (s *showdown) tally(results []Result, split [][]Card, weight float64) {
+	for i, cards := range split {
+		copy(s.full[i][len(s.full[i])-len(cards):], cards)
+	}
+	boards, hands := s.full[:len(s.boards)], s.full[len(s.boards):]
+
+	hi, lo := s.game.Showdown(boards, hands...)
+	boardWeight := weight / float64(len(boards))
+	for b := range boards {
+		for _, i := range hi[b] {
+			if len(hi[b]) == 1 {
+				results[i].HiWin += boardWeight
+			} else {
+				results[i].HiTie += boardWeight
+			}
+		}
+		for _, i := range lo[b] {
+			if len(lo[b]) == 1 {
+				results[i].LoWin += boardWeight
+			} else {
+				results[i].LoTie += boardWeight
+			}
+		}
+	}
+	for i, share := range PotShares(hi, lo, len(hands)) {
+		results[i].Equity += weight * share
+		if share == 1 {
+			results[i].Scoop += weight
+		}
+	}
+}
+
+func add(results, runouts []Result, weight float64) {
+	for i, r := range runouts {
+		results[i].Equity += weight * r.Equity
+		results[i].Scoop += weight * r.Scoop
+		results[i].HiWin += weight * r.HiWin
+		results[i].HiTie += weight * r.HiTie
+		results[i].LoWin += weight * r.LoWin
+		results[i].LoTie += weight * r.LoTie
+	}
+}
+
+func scale(results []Result, weight float64) {
+	runouts := append([]Result{}, results...)
+	for i := range results {
+		results[i] = Result{}
+	}
+	add(results, runouts, weight)
+}
+
+// weightedRange samples combos in proportion to their weights.
+type weightedRange struct {
+	Range
+	cumulative []float64
+}
+
+func newWeightedRange(r Range) weightedRange {
+	wr := weightedRange{r, make([]float64, len(r))}
+	total := 0.
+	for i, combo := range r {
+		total += combo.Weight
+		wr.cumulative[i] = total
+	}
+	return wr
+}
+
+func (wr weightedRange) sample(r *rand.Rand) Combo {
+	x := r.Float64() * wr.cumulative[len(wr.cumulative)-1]
+	i := sort.SearchFloat64s(wr.cumulative, x)
+	if i == len(wr.Range) {
+		i--
+	}
+	return wr.Range[i]
+}
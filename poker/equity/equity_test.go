@@ -0,0 +1,204 @@
+package equity
+
+import (
+	. "github.com/dohodges/fifty2"
+	. "github.com/dohodges/fifty2/poker"
+	"math"
+	"strings"
+	"testing"
+)
+
+func readCards(t *testing.T, s string) []Card {
+	cards, err := NewCardReader(strings.NewReader(s)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cards
+}
+
+func readRange(t *testing.T, hands ...string) Range {
+	r := make(Range, len(hands))
+	for i, hand := range hands {
+		r[i] = Combo{readCards(t, hand), 1}
+	}
+	return r
+}
+
+func assertEquity(t *testing.T, results []Result, expected ...float64) {
+	for i, r := range results {
+		if math.Abs(r.Equity-expected[i]) > 1e-9 {
+			t.Errorf("player %d equity %f, expected %f", i+1, r.Equity, expected[i])
+		}
+	}
+}
+
+func TestExact(t *testing.T) {
+	holdem := GetGame(Holdem)
+	board := readCards(t, "2c7d9h")
+
+	// kings win with a king and no ace, 83 of 990 runouts
+	results, err := Exact(Spot{Game: holdem, Board: board, Ranges: []Range{
+		readRange(t, "AsAd"),
+		readRange(t, "KsKd"),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEquity(t, results, 907./990, 83./990)
+	if results[0].Scoop != results[0].Equity || results[0].HiWin != results[0].Equity {
+		t.Errorf("aces scoop %f, win %f, equity %f", results[0].Scoop, results[0].HiWin, results[0].Equity)
+	}
+
+	// every pair of aces is the same against kings
+	results, err = Exact(Spot{Game: holdem, Board: board, Ranges: []Range{
+		readRange(t, "AsAd", "AsAh", "AsAc", "AdAh", "AdAc", "AhAc"),
+		readRange(t, "KsKd"),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEquity(t, results, 907./990, 83./990)
+
+	// queens are played three times as often as aces
+	aces := readRange(t, "AsAd")
+	queens := readRange(t, "QcQh")
+	queens[0].Weight = 3
+	results, err = Exact(Spot{Game: holdem, Board: board, Ranges: []Range{
+		append(aces, queens...),
+		readRange(t, "KsKd"),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEquity(t, results, (907.+3*83)/4/990, (83.+3*907)/4/990)
+
+	// kings that share a card with the known hand or dead cards are removed,
+	// leaving 946 runouts without the dead ace
+	results, err = Exact(Spot{Game: holdem, Board: board, Dead: readCards(t, "Ah"), Ranges: []Range{
+		readRange(t, "KsKd"),
+		readRange(t, "KsKc", "AsAh", "AsAd"),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEquity(t, results, 83./946, 863./946)
+}
+
+func TestExactSplits(t *testing.T) {
+	for _, spot := range []Spot{
+		{Game: GetGame(Holdem), Board: readCards(t, "2c7d9hTs"), Ranges: []Range{
+			readRange(t, "AsAd", "KsKd"),
+			readRange(t, "8c8h", "JcQc"),
+			readRange(t, "6c6h", "AhKh"),
+		}},
+		{Game: GetGame(OmahaHiLo), Board: readCards(t, "2c7d9hTs"), Ranges: []Range{
+			readRange(t, "AsAd3c4c"),
+			readRange(t, "8c8hJcQc", "As2s3h4h"),
+		}},
+		{Game: GetGame(HoldemDoubleBoard), Board: readCards(t, "2c7d9hKcQcJc"), Ranges: []Range{
+			readRange(t, "AsAd"),
+			readRange(t, "8c8h"),
+		}},
+	} {
+		results, err := Exact(spot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		total := 0.
+		for _, r := range results {
+			total += r.Equity
+		}
+		if math.Abs(total-1) > 1e-9 {
+			t.Errorf("%s equities %v total %f", spot.Game.Name, results, total)
+		}
+
+		approx, err := MonteCarlo(spot, 20000, NewSource(49))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range results {
+			if math.Abs(results[i].Equity-approx[i].Equity) > .02 || math.Abs(results[i].Scoop-approx[i].Scoop) > .02 {
+				t.Errorf("%s player %d exact %v, Monte Carlo %v", spot.Game.Name, i+1, results[i], approx[i])
+			}
+		}
+	}
+}
+
+func TestMonteCarlo(t *testing.T) {
+	spot := Spot{Game: GetGame(Holdem), Ranges: []Range{
+		readRange(t, "AsAd", "AsAh", "AsAc", "AdAh", "AdAc", "AhAc"),
+		readRange(t, "KsKd", "KsKh", "KsKc", "KdKh", "KdKc", "KhKc"),
+	}}
+	a, err := MonteCarlo(spot, 1000, NewSource(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := MonteCarlo(spot, 1000, NewSource(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a[0] != b[0] || a[1] != b[1] {
+		t.Errorf("same seed, different results %v and %v", a, b)
+	}
+	if math.Abs(a[0].Equity-.82) > .04 {
+		t.Errorf("aces against kings equity %f", a[0].Equity)
+	}
+}
+
+func TestMonteCarloRandomHands(t *testing.T) {
+	for _, gt := range []GameType{Holdem, Stud7} {
+		spot := Spot{Game: GetGame(gt), Ranges: []Range{HandRange(nil), HandRange(nil)}}
+		results, err := MonteCarlo(spot, 2000, NewSource(3))
+		if err != nil {
+			t.Fatalf("%s random hands - %v", gt, err)
+		}
+		if math.Abs(results[0].Equity-.5) > .05 || math.Abs(results[0].Equity+results[1].Equity-1) > 1e-9 {
+			t.Errorf("%s random hand equities %f and %f", gt, results[0].Equity, results[1].Equity)
+		}
+	}
+}
+
+func TestRangeOnBoard(t *testing.T) {
+	holdem := GetGame(Holdem)
+	board := readCards(t, "AhKd7c")
+	aces, err := ParseRange("AA, 7c7d")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the aces and sevens holding a board card are dropped
+	results, err := Exact(Spot{Game: holdem, Board: board, Ranges: []Range{aces, readRange(t, "KsKc")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := Exact(Spot{Game: holdem, Board: board, Ranges: []Range{
+		readRange(t, "AsAd", "AsAc", "AdAc"),
+		readRange(t, "KsKc"),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEquity(t, results, expected[0].Equity, expected[1].Equity)
+
+	if _, err := MonteCarlo(Spot{Game: holdem, Board: board, Ranges: []Range{aces, readRange(t, "KsKc")}}, 10, NewSource(1)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSpotErrors(t *testing.T) {
+	holdem := GetGame(Holdem)
+	for _, spot := range []Spot{
+		{Game: holdem, Ranges: []Range{readRange(t, "AsAd")}},
+		{Game: holdem, Ranges: []Range{readRange(t, "AsAd"), readRange(t, "AsAd")}},
+		{Game: holdem, Board: readCards(t, "AsKs"), Ranges: []Range{readRange(t, "QsQd"), readRange(t, "JsJd")}},
+		{Game: holdem, Board: readCards(t, "AsKsQs"), Ranges: []Range{readRange(t, "AsAd"), readRange(t, "JsJd")}},
+		{Game: holdem, Ranges: []Range{readRange(t, "AsAdAh"), readRange(t, "JsJd")}},
+	} {
+		if _, err := Exact(spot); err == nil {
+			t.Errorf("calculated exact equity for %v", spot)
+		}
+		if _, err := MonteCarlo(spot, 10, NewSource(1)); err == nil {
+			t.Errorf("calculated Monte Carlo equity for %v", spot)
+		}
+	}
+}
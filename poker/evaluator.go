@@ -0,0 +1,182 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+)
+
+// Evaluator is a set of rules for ranking poker hands. Strengths from
+// different evaluators are not comparable; each evaluator orders its own.
+type Evaluator interface {
+	Evaluate(hand []Card) HandStrength
+	// Compare returns a positive number if a beats b, a negative number if b
+	// beats a and 0 if they tie. NoHand loses to every hand.
+	Compare(a, b HandStrength) int
+	Describe(strength HandStrength) string
+	// BestFive returns the five cards that make the best hand and its
+	// strength, or nil if there is no qualifying hand.
+	BestFive(hand []Card) ([]Card, HandStrength)
+}
+
+var (
+	High          Evaluator = HighEvaluator{}
+	AceToFiveLow  Evaluator = LowEvaluator{EightOrBetter: false}
+	AceToFiveLow8 Evaluator = LowEvaluator{EightOrBetter: true}
+	DeuceToSeven  Evaluator = DeuceToSevenEvaluator{}
+)
+
+type HighEvaluator struct{}
+
+func (HighEvaluator) Evaluate(hand []Card) HandStrength {
+	return GetHandStrength(hand)
+}
+
+func (HighEvaluator) Compare(a, b HandStrength) int {
+	return compareHigh(a, b)
+}
+
+func (HighEvaluator) Describe(strength HandStrength) string {
+	return DescribeHigh(strength)
+}
+
+func (e HighEvaluator) BestFive(hand []Card) ([]Card, HandStrength) {
+	return BestFive(e, hand)
+}
+
+// LowEvaluator ranks ace-to-five lowball hands, where aces are low and
+// straights and flushes don't count.
+type LowEvaluator struct {
+	EightOrBetter bool
+}
+
+func (e LowEvaluator) Evaluate(hand []Card) HandStrength {
+	return GetLowHandStrength(hand, e.EightOrBetter)
+}
+
+func (LowEvaluator) Compare(a, b HandStrength) int {
+	return compareLow(a, b)
+}
+
+func (LowEvaluator) Describe(strength HandStrength) string {
+	return DescribeLow(strength)
+}
+
+func (e LowEvaluator) BestFive(hand []Card) ([]Card, HandStrength) {
+	return BestFive(e, hand)
+}
+
+func compareHigh(a, b HandStrength) int {
+	switch {
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	}
+	return 0
+}
+
+func compareLow(a, b HandStrength) int {
+	switch {
+	case a == b:
+		return 0
+	case a.Rank() == NoHand:
+		return -1
+	case b.Rank() == NoHand:
+		return 1
+	case a < b:
+		return 1
+	}
+	return -1
+}
+
+// BestFive returns the best five card hand in hand according to e. Hands of
+// five cards or fewer are returned whole.
+func BestFive(e Evaluator, hand []Card) ([]Card, HandStrength) {
+	if len(hand) <= 5 {
+		strength := e.Evaluate(hand)
+		if strength.Rank() == NoHand {
+			return nil, strength
+		}
+		return append([]Card{}, hand...), strength
+	}
+
+	var best []Card
+	bestStrength := MakeHandStrength(NoHand, 0, 0, 0)
+	for itr := Combinations(hand, 5); itr.HasNext(); {
+		five := itr.Next()
+		strength := e.Evaluate(five)
+		if strength.Rank() != NoHand && (best == nil || e.Compare(strength, bestStrength) > 0) {
+			best, bestStrength = five, strength
+		}
+	}
+	return best, bestStrength
+}
+
+// Best returns the indexes of the strongest hands in strengths. Hands without
+// a qualifying strength never win.
+func Best(e Evaluator, strengths []HandStrength) []int {
+	best := make([]int, 0, len(strengths))
+	for i, strength := range strengths {
+		if strength.Rank() == NoHand {
+			continue
+		}
+		if len(best) == 0 {
+			best = append(best, i)
+			continue
+		}
+		switch c := e.Compare(strength, strengths[best[0]]); {
+		case c > 0:
+			best = append(best[0:0], i)
+		case c == 0:
+			best = append(best, i)
+		}
+	}
+	return best
+}
+
+// DeuceToSevenEvaluator ranks deuce-to-seven lowball hands, the worst high
+// hand wins. Aces are always high, so A-2-3-4-5 is not a straight, and
+// straights and flushes count against the hand.
+type DeuceToSevenEvaluator struct{}
+
+func (e DeuceToSevenEvaluator) Evaluate(hand []Card) HandStrength {
+	if len(hand) <= 5 {
+		return getDeuceToSevenStrength(hand)
+	}
+
+	best := MakeHandStrength(NoHand, 0, 0, 0)
+	for itr := Combinations(hand, 5); itr.HasNext(); {
+		if strength := getDeuceToSevenStrength(itr.Next()); e.Compare(strength, best) > 0 {
+			best = strength
+		}
+	}
+	return best
+}
+
+func getDeuceToSevenStrength(hand []Card) HandStrength {
+	strength := GetHandStrength(hand)
+	if strength.strength1() != CardStrength(Five) {
+		return strength
+	}
+
+	// A-2-3-4-5 is ace high
+	kickers := AceHigh.Mask() | 0x001E
+	switch strength.Rank() {
+	case StraightFlush:
+		return MakeHandStrength(Flush, 0, 0, kickers)
+	case Straight:
+		return MakeHandStrength(HighCard, 0, 0, kickers)
+	}
+	return strength
+}
+
+func (DeuceToSevenEvaluator) Compare(a, b HandStrength) int {
+	return compareLow(a, b)
+}
+
+func (DeuceToSevenEvaluator) Describe(strength HandStrength) string {
+	return DescribeDeuceToSeven(strength)
+}
+
+func (e DeuceToSevenEvaluator) BestFive(hand []Card) ([]Card, HandStrength) {
+	return BestFive(e, hand)
+}
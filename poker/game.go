@@ -1,7 +1,10 @@
 package poker
 
 import (
+	"fmt"
 	. "github.com/dohodges/fifty2"
+	"sort"
+	"sync"
 )
 
 type GameType string
@@ -14,96 +17,674 @@ const (
 	Stud7HiLo GameType = "stud7hl"
 	Stud5     GameType = "stud5"
 	Razz      GameType = "razz"
+	ShortDeck GameType = "shortdeck"
+	// ShortDeckStraights is short deck with straights beating three of a kind.
+	ShortDeckStraights GameType = "shortdeckst"
+	DeuceToSevenSingle GameType = "27single"
+	DeuceToSevenTriple GameType = "27triple"
+	Badugi             GameType = "badugi"
+	Badeucy            GameType = "badeucy"
+	Badacey            GameType = "badacey"
+	Pineapple          GameType = "pineapple"
+	CrazyPineapple     GameType = "crazypineapple"
+	Courchevel         GameType = "courchevel"
+	CourchevelHiLo     GameType = "courchevelhl"
+	Omaha5             GameType = "omaha5"
+	Omaha5HiLo         GameType = "omaha5hl"
+	Omaha6             GameType = "omaha6"
+	Omaha6HiLo         GameType = "omaha6hl"
+	// BigO is five card Omaha Hi/Lo by its common name.
+	BigO         GameType = Omaha5HiLo
+	FiveCardDraw GameType = "5draw"
+	// CaliforniaLowball is ace-to-five lowball draw with a joker.
+	CaliforniaLowball GameType = "a5lowball"
+	// Double board games split the pot between two boards, as in bomb pots.
+	HoldemDoubleBoard    GameType = "holdemdb"
+	OmahaDoubleBoard     GameType = "omahadb"
+	OmahaHiLoDoubleBoard GameType = "omahahldb"
 )
 
+// GameStrengthFunc was the type of the HiStrength and LoStrength fields of
+// Game, which are now the Hi and Lo evaluators. The HiStrength and LoStrength
+// methods score a hand with them.
+//
+// Deprecated: set Game.Hi and Game.Lo to an Evaluator instead.
 type GameStrengthFunc func(board, hand []Card) HandStrength
 
 type Game struct {
-	Name       string
-	HandSize   int
-	BoardSize  int
-	HiStrength GameStrengthFunc
-	LoStrength GameStrengthFunc
+	Name      string
+	HandSize  int
+	BoardSize int
+	// PocketCards is the exact number of pocket cards a hand must use, as in
+	// Omaha. 0 allows any combination of pocket and board cards.
+	PocketCards int
+	// Hi and Lo each win half the pot. Split lowball games, such as Badeucy,
+	// play the first half as Hi.
+	Hi   Evaluator
+	Lo   Evaluator
+	Deal DealScript
+	// Discards is the number of cards each seat discards once the board has
+	// DiscardAt cards, as in Pineapple.
+	Discards  int
+	DiscardAt int
+	// Draws is the number of drawing rounds in draw games. A hand can draw
+	// to any of its cards in each round.
+	Draws int
+	// Openers is the least Hi hand that can open the betting in draw games,
+	// such as a pair of jacks. 0 allows any hand.
+	Openers HandStrength
+	// Deck returns a new deck for the game. nil is the standard 52 card deck.
+	Deck func() []Card
+	// Boards is the number of boards of BoardSize cards, each deciding an
+	// equal share of the pot. 0 is a single board. Each round deals its
+	// board cards to every board in turn.
+	Boards int
 }
 
 func (g Game) HasHiHand() bool {
-	return g.HiStrength != nil
+	return g.Hi != nil
 }
 
 func (g Game) HasLoHand() bool {
-	return g.LoStrength != nil
+	return g.Lo != nil
+}
+
+// CanOpen reports whether hand holds the openers needed to open the betting.
+func (g Game) CanOpen(hand []Card) bool {
+	if g.Openers == 0 {
+		return true
+	}
+	return g.HasHiHand() && g.Hi.Compare(g.Hi.Evaluate(hand), g.Openers) >= 0
+}
+
+// KeptCards returns the number of cards in a hand after discarding.
+func (g Game) KeptCards() int {
+	return g.HandSize - g.Discards
+}
+
+// mustDiscard reports whether pocket has cards left to discard.
+func (g Game) mustDiscard(pocket []Card) bool {
+	return g.Discards > 0 && len(pocket) > g.KeptCards()
+}
+
+// keep returns the cards pocket plays. Hands with cards left to discard keep
+// the cards that make the best hand with the board they discard on, its first
+// DiscardAt cards, judged high unless the game is only played for low. Later
+// board cards can't change the decision, so Pineapple hands keep their best
+// two cards before the flop and Crazy Pineapple hands their best on the flop.
+func (g Game) keep(board, pocket []Card) []Card {
+	if !g.mustDiscard(pocket) {
+		return pocket
+	}
+	if len(board) > g.DiscardAt {
+		board = board[:g.DiscardAt]
+	}
+	e := g.Hi
+	if !g.HasHiHand() {
+		e = g.Lo
+	}
+
+	var kept []Card
+	var best HandStrength
+	for itr := Combinations(pocket, g.KeptCards()); itr.HasNext(); {
+		cards := itr.Next()
+		if strength := g.strength(e, board, cards); kept == nil || e.Compare(strength, best) > 0 {
+			kept, best = cards, strength
+		}
+	}
+	return kept
+}
+
+// BoardCount returns the number of boards dealt.
+func (g Game) BoardCount() int {
+	if g.Boards < 1 {
+		return 1
+	}
+	return g.Boards
+}
+
+func (g Game) NewDeck() []Card {
+	if g.Deck == nil {
+		return NewDeck()
+	}
+	return g.Deck()
+}
+
+func (g Game) HiStrength(board, pocket []Card) HandStrength {
+	return g.strength(g.Hi, board, pocket)
+}
+
+func (g Game) LoStrength(board, pocket []Card) HandStrength {
+	return g.strength(g.Lo, board, pocket)
+}
+
+func (g Game) strength(e Evaluator, board, pocket []Card) HandStrength {
+	pocket = g.keep(board, pocket)
+	if g.PocketCards == 0 {
+		hand := make([]Card, 0, len(pocket)+len(board))
+		hand = append(hand, pocket...)
+		return e.Evaluate(append(hand, board...))
+	}
+
+	return omahaStrength(e, board, pocket, g.PocketCards)
+}
+
+// omahaStrength returns the best hand made from exactly pocketCards pocket
+// cards and the rest from the board. Incomplete boards are used whole. The
+// board combinations are found once and shared by every pocket combination.
+func omahaStrength(e Evaluator, board, pocket []Card, pocketCards int) HandStrength {
+	best := MakeHandStrength(NoHand, 0, 0, 0)
+	boardCards := 5 - pocketCards
+	if boardCards > len(board) {
+		boardCards = len(board)
+	}
+	if pocketCards > len(pocket) {
+		return best
+	}
+
+	if _, ok := e.(HighEvaluator); ok {
+		if strength, ok := omahaHighStrength(board, pocket, pocketCards, boardCards); ok {
+			return strength
+		}
+	}
+
+	boards := make([][]Card, 0, 20)
+	for itr := Combinations(board, boardCards); itr.HasNext(); {
+		boards = append(boards, itr.Next())
+	}
+
+	hand := make([]Card, pocketCards+boardCards)
+	for itr := Combinations(pocket, pocketCards); itr.HasNext(); {
+		copy(hand, itr.Next())
+		for _, cards := range boards {
+			copy(hand[pocketCards:], cards)
+			if strength := e.Evaluate(hand); e.Compare(strength, best) > 0 {
+				best = strength
+			}
+		}
+	}
+	return best
 }
 
 func (g Game) IsHiLo() bool {
 	return g.HasHiHand() && g.HasLoHand()
 }
 
-var games map[GameType]Game
+var (
+	holdemDeal = DealScript{
+		DealRound{DealStep{DealDown, 2}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 3}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+	}
+
+	omahaDeal = DealScript{
+		DealRound{DealStep{DealDown, 4}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 3}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+	}
+
+	omaha5Deal = DealScript{
+		DealRound{DealStep{DealDown, 5}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 3}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+	}
+
+	omaha6Deal = DealScript{
+		DealRound{DealStep{DealDown, 6}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 3}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+	}
+
+	pineappleDeal = DealScript{
+		DealRound{DealStep{DealDown, 3}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 3}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+	}
+
+	// the first board card is dealt face up with the hands
+	courchevelDeal = DealScript{
+		DealRound{DealStep{DealDown, 5}, DealStep{DealBoard, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 2}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+	}
+
+	stud7Deal = DealScript{
+		DealRound{DealStep{DealDown, 2}, DealStep{DealUp, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealUp, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealUp, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealUp, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealDown, 1}},
+	}
+
+	stud5Deal = DealScript{
+		DealRound{DealStep{DealDown, 1}, DealStep{DealUp, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealUp, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealUp, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealUp, 1}},
+	}
+
+	drawDeal = DealScript{
+		DealRound{DealStep{DealDown, 5}},
+	}
+
+	badugiDeal = DealScript{
+		DealRound{DealStep{DealDown, 4}},
+	}
+
+	holdemDoubleBoardDeal = DealScript{
+		DealRound{DealStep{DealDown, 2}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 3}, DealStep{DealBurn, 1}, DealStep{DealBoard, 3}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}, DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}, DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+	}
+
+	omahaDoubleBoardDeal = DealScript{
+		DealRound{DealStep{DealDown, 4}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 3}, DealStep{DealBurn, 1}, DealStep{DealBoard, 3}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}, DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+		DealRound{DealStep{DealBurn, 1}, DealStep{DealBoard, 1}, DealStep{DealBurn, 1}, DealStep{DealBoard, 1}},
+	}
+)
+
+var (
+	games   map[GameType]Game
+	gamesMu sync.RWMutex
+)
 
 func init() {
 
 	games = map[GameType]Game{
 
 		Holdem: Game{
-			Name:       "Texas Hold'em",
-			HandSize:   2,
-			BoardSize:  5,
-			HiStrength: GetHoldemHandStrength,
-			LoStrength: nil,
+			Name:      "Texas Hold'em",
+			HandSize:  2,
+			BoardSize: 5,
+			Hi:        High,
+			Deal:      holdemDeal,
 		},
 
 		Omaha: Game{
-			Name:       "Omaha",
-			HandSize:   4,
-			BoardSize:  5,
-			HiStrength: GetOmahaHandStrength,
-			LoStrength: nil,
+			Name:        "Omaha",
+			HandSize:    4,
+			BoardSize:   5,
+			PocketCards: 2,
+			Hi:          High,
+			Deal:        omahaDeal,
 		},
 
 		OmahaHiLo: Game{
-			Name:       "Omaha Hi/Lo",
-			HandSize:   4,
-			BoardSize:  5,
-			HiStrength: GetOmahaHandStrength,
-			LoStrength: GetOmahaLowHandStrength,
+			Name:        "Omaha Hi/Lo",
+			HandSize:    4,
+			BoardSize:   5,
+			PocketCards: 2,
+			Hi:          High,
+			Lo:          AceToFiveLow8,
+			Deal:        omahaDeal,
+		},
+
+		Omaha5: Game{
+			Name:        "5-card Omaha",
+			HandSize:    5,
+			BoardSize:   5,
+			PocketCards: 2,
+			Hi:          High,
+			Deal:        omaha5Deal,
+		},
+
+		Omaha5HiLo: Game{
+			Name:        "5-card Omaha Hi/Lo",
+			HandSize:    5,
+			BoardSize:   5,
+			PocketCards: 2,
+			Hi:          High,
+			Lo:          AceToFiveLow8,
+			Deal:        omaha5Deal,
+		},
+
+		Omaha6: Game{
+			Name:        "6-card Omaha",
+			HandSize:    6,
+			BoardSize:   5,
+			PocketCards: 2,
+			Hi:          High,
+			Deal:        omaha6Deal,
+		},
+
+		Omaha6HiLo: Game{
+			Name:        "6-card Omaha Hi/Lo",
+			HandSize:    6,
+			BoardSize:   5,
+			PocketCards: 2,
+			Hi:          High,
+			Lo:          AceToFiveLow8,
+			Deal:        omaha6Deal,
+		},
+
+		HoldemDoubleBoard: Game{
+			Name:      "Double Board Hold'em",
+			HandSize:  2,
+			BoardSize: 5,
+			Boards:    2,
+			Hi:        High,
+			Deal:      holdemDoubleBoardDeal,
+		},
+
+		OmahaDoubleBoard: Game{
+			Name:        "Double Board Omaha",
+			HandSize:    4,
+			BoardSize:   5,
+			Boards:      2,
+			PocketCards: 2,
+			Hi:          High,
+			Deal:        omahaDoubleBoardDeal,
+		},
+
+		OmahaHiLoDoubleBoard: Game{
+			Name:        "Double Board Omaha Hi/Lo",
+			HandSize:    4,
+			BoardSize:   5,
+			Boards:      2,
+			PocketCards: 2,
+			Hi:          High,
+			Lo:          AceToFiveLow8,
+			Deal:        omahaDoubleBoardDeal,
 		},
 
 		Stud7: Game{
-			Name:       "7-card Stud",
-			HandSize:   7,
-			BoardSize:  0,
-			HiStrength: func(board, hand []Card) HandStrength { return GetHandStrength(hand) },
-			LoStrength: nil,
+			Name:      "7-card Stud",
+			HandSize:  7,
+			BoardSize: 0,
+			Hi:        High,
+			Deal:      stud7Deal,
 		},
 
 		Stud7HiLo: Game{
-			Name:       "7-card Stud Hi/Lo",
-			HandSize:   7,
-			BoardSize:  0,
-			HiStrength: func(board, hand []Card) HandStrength { return GetHandStrength(hand) },
-			LoStrength: func(board, hand []Card) HandStrength { return GetLowHandStrength(hand, true) },
+			Name:      "7-card Stud Hi/Lo",
+			HandSize:  7,
+			BoardSize: 0,
+			Hi:        High,
+			Lo:        AceToFiveLow8,
+			Deal:      stud7Deal,
 		},
 
 		Stud5: Game{
-			Name:       "5-card Stud",
-			HandSize:   5,
-			BoardSize:  0,
-			HiStrength: func(board, hand []Card) HandStrength { return GetHandStrength(hand) },
-			LoStrength: nil,
+			Name:      "5-card Stud",
+			HandSize:  5,
+			BoardSize: 0,
+			Hi:        High,
+			Deal:      stud5Deal,
 		},
 
 		Razz: Game{
-			Name:       "Razz",
-			HandSize:   7,
-			BoardSize:  0,
-			HiStrength: nil,
-			LoStrength: func(board, hand []Card) HandStrength { return GetLowHandStrength(hand, false) },
+			Name:      "Razz",
+			HandSize:  7,
+			BoardSize: 0,
+			Lo:        AceToFiveLow,
+			Deal:      stud7Deal,
+		},
+
+		ShortDeck: Game{
+			Name:      "Short Deck Hold'em",
+			HandSize:  2,
+			BoardSize: 5,
+			Hi:        ShortDeckTripsHigh,
+			Deal:      holdemDeal,
+			Deck:      NewShortDeck,
+		},
+
+		ShortDeckStraights: Game{
+			Name:      "Short Deck Hold'em (straights beat trips)",
+			HandSize:  2,
+			BoardSize: 5,
+			Hi:        ShortDeckHigh,
+			Deal:      holdemDeal,
+			Deck:      NewShortDeck,
+		},
+
+		FiveCardDraw: Game{
+			Name:      "5-card Draw",
+			HandSize:  5,
+			BoardSize: 0,
+			Hi:        High,
+			Deal:      drawDeal,
+			Draws:     1,
+			Openers:   MakeHandStrength(Pair, CardStrength(Jack), 0, 0),
+		},
+
+		CaliforniaLowball: Game{
+			Name:      "California Lowball",
+			HandSize:  5,
+			BoardSize: 0,
+			Lo:        JokerLow,
+			Deal:      drawDeal,
+			Draws:     1,
+			Deck:      func() []Card { return NewJokerDeck(1) },
+		},
+
+		DeuceToSevenSingle: Game{
+			Name:      "2-7 Single Draw",
+			HandSize:  5,
+			BoardSize: 0,
+			Lo:        DeuceToSeven,
+			Deal:      drawDeal,
+			Draws:     1,
+		},
+
+		DeuceToSevenTriple: Game{
+			Name:      "2-7 Triple Draw",
+			HandSize:  5,
+			BoardSize: 0,
+			Lo:        DeuceToSeven,
+			Deal:      drawDeal,
+			Draws:     3,
+		},
+
+		Badugi: Game{
+			Name:      "Badugi",
+			HandSize:  4,
+			BoardSize: 0,
+			Lo:        BadugiLow,
+			Deal:      badugiDeal,
+			Draws:     3,
+		},
+
+		Badeucy: Game{
+			Name:      "Badeucy",
+			HandSize:  5,
+			BoardSize: 0,
+			Hi:        BadugiLow,
+			Lo:        DeuceToSeven,
+			Deal:      drawDeal,
+			Draws:     3,
+		},
+
+		Badacey: Game{
+			Name:      "Badacey",
+			HandSize:  5,
+			BoardSize: 0,
+			Hi:        BadugiLow,
+			Lo:        AceToFiveLow,
+			Deal:      drawDeal,
+			Draws:     3,
+		},
+
+		Pineapple: Game{
+			Name:      "Pineapple",
+			HandSize:  3,
+			BoardSize: 5,
+			Hi:        High,
+			Deal:      pineappleDeal,
+			Discards:  1,
+			DiscardAt: 0,
+		},
+
+		CrazyPineapple: Game{
+			Name:      "Crazy Pineapple",
+			HandSize:  3,
+			BoardSize: 5,
+			Hi:        High,
+			Deal:      pineappleDeal,
+			Discards:  1,
+			DiscardAt: 3,
+		},
+
+		Courchevel: Game{
+			Name:        "Courchevel",
+			HandSize:    5,
+			BoardSize:   5,
+			PocketCards: 2,
+			Hi:          High,
+			Deal:        courchevelDeal,
+		},
+
+		CourchevelHiLo: Game{
+			Name:        "Courchevel Hi/Lo",
+			HandSize:    5,
+			BoardSize:   5,
+			PocketCards: 2,
+			Hi:          High,
+			Lo:          AceToFiveLow8,
+			Deal:        courchevelDeal,
 		},
 	}
 }
 
+// GetGame returns the registered game, or a zero Game if gt is unknown.
 func GetGame(gt GameType) Game {
-	return games[gt]
+	game, _ := LookupGame(gt)
+	return game
+}
+
+func LookupGame(gt GameType) (Game, error) {
+	gamesMu.RLock()
+	defer gamesMu.RUnlock()
+	game, ok := games[gt]
+	if !ok {
+		return Game{}, fmt.Errorf("fifty2/poker: unknown game[%s]", gt)
+	}
+	return game, nil
+}
+
+// Games returns the registered game types in order.
+func Games() []GameType {
+	gamesMu.RLock()
+	defer gamesMu.RUnlock()
+	types := make([]GameType, 0, len(games))
+	for gt := range games {
+		types = append(types, gt)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// RegisterGame adds a game, such as a house variant, so it can be looked up
+// by type. Games can't be registered twice.
+func RegisterGame(gt GameType, game Game) error {
+	if gt == "" {
+		return fmt.Errorf("fifty2/poker: game type is empty")
+	}
+	if err := game.check(); err != nil {
+		return fmt.Errorf("fifty2/poker: game[%s] %v", gt, err)
+	}
+
+	gamesMu.Lock()
+	defer gamesMu.Unlock()
+	if _, ok := games[gt]; ok {
+		return fmt.Errorf("fifty2/poker: game[%s] is already registered", gt)
+	}
+	games[gt] = game
+	return nil
+}
+
+func (g Game) check() error {
+	switch {
+	case g.Name == "":
+		return fmt.Errorf("has no name")
+	case !g.HasHiHand() && !g.HasLoHand():
+		return fmt.Errorf("has no evaluator")
+	case g.Discards < 0 || g.Discards >= g.HandSize:
+		return fmt.Errorf("discards %d cards from a hand of %d", g.Discards, g.HandSize)
+	case g.PocketCards > g.KeptCards():
+		return fmt.Errorf("uses %d pocket cards from a hand of %d", g.PocketCards, g.KeptCards())
+	case g.Deal == nil:
+		return fmt.Errorf("has no deal")
+	case g.Deal.CardsPerSeat() != g.HandSize:
+		return fmt.Errorf("deals %d cards per seat, hand size is %d", g.Deal.CardsPerSeat(), g.HandSize)
+	case g.Draws < 0:
+		return fmt.Errorf("has %d draws", g.Draws)
+	case g.Openers != 0 && !g.HasHiHand():
+		return fmt.Errorf("needs openers without a Hi hand")
+	case g.Boards < 0:
+		return fmt.Errorf("has %d boards", g.Boards)
+	case g.Deal.BoardSize() != g.BoardSize*g.BoardCount():
+		return fmt.Errorf("deals %d board cards, board size is %d on %d boards", g.Deal.BoardSize(), g.BoardSize, g.BoardCount())
+	case !g.dealsBoardsEvenly():
+		return fmt.Errorf("deals board cards that can't be shared by %d boards", g.BoardCount())
+	case g.Discards > 0 && !g.isBoardSize(g.DiscardAt):
+		return fmt.Errorf("discards with %d board cards, which is never dealt", g.DiscardAt)
+	case g.Discards > 0 && g.BoardCount() > 1:
+		return fmt.Errorf("discards on %d boards", g.BoardCount())
+	}
+	return nil
+}
+
+func (g Game) dealsBoardsEvenly() bool {
+	for _, size := range g.Deal.BoardSizes() {
+		if size%g.BoardCount() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (g Game) isBoardSize(n int) bool {
+	for _, size := range g.Deal.BoardSizes() {
+		if n == size {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks a board and hands, which may be incomplete, against the
+// game. Cards must be in the game's deck and dealt once, hands can't be
+// larger than the hand size and the board must be a size that is dealt.
+func (g Game) Validate(board []Card, hands ...[]Card) error {
+	if !g.isBoardSize(len(board)) {
+		return fmt.Errorf("fifty2/poker: %s board can't have %d cards, expected one of %v", g.Name, len(board), g.Deal.BoardSizes())
+	}
+	for _, hand := range hands {
+		if len(hand) > g.HandSize {
+			return fmt.Errorf("fifty2/poker: %s hand %v has more than %d cards", g.Name, hand, g.HandSize)
+		}
+	}
+
+	deck := g.NewDeck()
+	seen := uint64(0)
+	for _, cards := range append([][]Card{board}, hands...) {
+		for _, card := range cards {
+			if Index(deck, card) < 0 {
+				return fmt.Errorf("fifty2/poker: %s is not in the %s deck", card, g.Name)
+			}
+			if seen&card.Mask() != 0 {
+				return fmt.Errorf("fifty2/poker: %s is dealt more than once", card)
+			}
+			seen |= card.Mask()
+		}
+	}
+	return nil
+}
+
+// NewDealer returns a dealer for the given number of seats that deals from
+// deck according to the game's dealing procedure.
+func (g Game) NewDealer(seats int, deck []Card) *Dealer {
+	return NewDealer(g.Deal, seats, deck)
 }
 
 func GetHoldemHandStrength(board, pocket []Card) HandStrength {
@@ -114,31 +695,9 @@ func GetHoldemHandStrength(board, pocket []Card) HandStrength {
 }
 
 func GetOmahaHandStrength(board, pocket []Card) HandStrength {
-	strengths := make([]HandStrength, 0, 6)
-	for itr := Combinations(pocket, 2); itr.HasNext(); {
-		hand := make([]Card, 7)
-		copy(hand, itr.Next())
-		copy(hand[2:], board)
-		strengths = append(strengths, GetHandStrength(hand))
-	}
-	return MaxHandStrength(strengths)
+	return omahaStrength(High, board, pocket, 2)
 }
 
 func GetOmahaLowHandStrength(board, pocket []Card) HandStrength {
-	strengths := make([]HandStrength, 0, 6)
-	for itr := Combinations(pocket, 2); itr.HasNext(); {
-		hand := make([]Card, 7)
-		copy(hand, itr.Next())
-		copy(hand[2:], board)
-		strength := GetLowHandStrength(hand, true)
-		if strength.Rank() != NoHand {
-			strengths = append(strengths, strength)
-		}
-	}
-
-	if len(strengths) > 0 {
-		return MinHandStrength(strengths)
-	}
-
-	return MakeHandStrength(NoHand, 0, 0, 0)
+	return omahaStrength(AceToFiveLow8, board, pocket, 2)
 }
@@ -0,0 +1,359 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"reflect"
+	"testing"
+)
+
+func TestGameDeal(t *testing.T) {
+	for gt, game := range games {
+		if err := game.check(); err != nil {
+			t.Errorf("%s %v", gt, err)
+		}
+	}
+}
+
+func TestStudDeal(t *testing.T) {
+	dealer := GetGame(Stud7).NewDealer(8, NewDeck())
+	if err := dealer.DealAll(); err == nil {
+		t.Errorf("dealt 7 card stud to 8 seats without running out of cards")
+	}
+	if dealer.Round() != 4 {
+		t.Errorf("expected 6th street to be dealt, dealt %d rounds", dealer.Round())
+	}
+	if up := dealer.View(1).Upcards[0]; len(up) != 4 {
+		t.Errorf("expected 4 upcards on 6th street - %v", up)
+	}
+}
+
+func TestEvaluators(t *testing.T) {
+	hand := []Card{
+		Card{Ace, Spades},
+		Card{Two, Hearts},
+		Card{Three, Diamonds},
+		Card{Four, Clubs},
+		Card{Five, Spades},
+		Card{King, Spades},
+		Card{King, Hearts},
+	}
+
+	five, strength := High.BestFive(hand)
+	if strength != GetHandStrength(hand) || GetHandStrength(five) != strength || len(five) != 5 {
+		t.Errorf("best high five %v %#X", five, strength)
+	}
+
+	five, strength = AceToFiveLow8.BestFive(hand)
+	if strength != GetLowHandStrength(hand, true) || Mask(five) != Mask(hand[:5]) {
+		t.Errorf("best low five %v %#X", five, strength)
+	}
+
+	five, strength = AceToFiveLow8.BestFive(hand[4:])
+	if five != nil || strength.Rank() != NoHand {
+		t.Errorf("low hand from %v - %v %#X", hand[4:], five, strength)
+	}
+
+	wheel := GetLowHandStrength(hand, false)
+	sixLow := MakeHandStrength(HighCard, 0, 0, 0x002F)
+	noLow := MakeHandStrength(NoHand, 0, 0, 0)
+	if AceToFiveLow.Compare(wheel, sixLow) <= 0 || AceToFiveLow.Compare(sixLow, wheel) >= 0 {
+		t.Errorf("wheel does not beat a six low")
+	}
+	if AceToFiveLow8.Compare(sixLow, noLow) <= 0 || AceToFiveLow8.Compare(noLow, noLow) != 0 {
+		t.Errorf("no low hand beats a six low")
+	}
+
+	if best := Best(AceToFiveLow8, []HandStrength{noLow, sixLow, wheel, wheel}); len(best) != 2 || best[0] != 2 || best[1] != 3 {
+		t.Errorf("best low hands %v", best)
+	}
+	if best := Best(AceToFiveLow8, []HandStrength{noLow, noLow}); len(best) != 0 {
+		t.Errorf("best low hands %v", best)
+	}
+}
+
+func TestGameStrength(t *testing.T) {
+	board := []Card{Card{Ace, Hearts}, Card{King, Hearts}, Card{Two, Clubs}, Card{Seven, Diamonds}, Card{Nine, Spades}}
+	pocket := []Card{Card{Ace, Spades}, Card{Three, Clubs}}
+	if strength := GetGame(Holdem).HiStrength(board, pocket); strength != GetHoldemHandStrength(board, pocket) {
+		t.Errorf("holdem strength %#X", strength)
+	}
+
+	pocket = []Card{Card{Ace, Spades}, Card{Three, Clubs}, Card{Four, Clubs}, Card{Queen, Diamonds}}
+	if strength := GetGame(OmahaHiLo).HiStrength(board, pocket); strength != GetOmahaHandStrength(board, pocket) {
+		t.Errorf("omaha strength %#X", strength)
+	}
+	if strength := GetGame(OmahaHiLo).LoStrength(board, pocket); strength != GetOmahaLowHandStrength(board, pocket) {
+		t.Errorf("omaha low strength %#X", strength)
+	}
+}
+
+func TestOmahaUsesTwoPocketCards(t *testing.T) {
+	omaha := GetGame(OmahaHiLo)
+
+	// four flush board with one suited hole card is not a flush
+	board := readCards(t, "AhKh7h2h9s")
+	pocket := readCards(t, "QhJcJdTs")
+	if strength := omaha.HiStrength(board, pocket); strength.Rank() != Pair {
+		t.Errorf("%v on %v is %s, expected Pair", pocket, board, DescribeHigh(strength))
+	}
+	if strength := GetOmahaHandStrength(board, pocket); strength.Rank() != Pair {
+		t.Errorf("%v on %v is %s, expected Pair", pocket, board, DescribeHigh(strength))
+	}
+
+	// four to a straight on board with one hole card is not a straight
+	board = readCards(t, "9cThJdQs2h")
+	pocket = readCards(t, "Kc3d4s5h")
+	if strength := omaha.HiStrength(board, pocket); strength.Rank() != HighCard {
+		t.Errorf("%v on %v is %s, expected High Card", pocket, board, DescribeHigh(strength))
+	}
+
+	// two hole cards with three board cards still count
+	pocket = readCards(t, "KcAd4s5h")
+	if strength := omaha.HiStrength(board, pocket); strength != MakeHandStrength(Straight, AceHigh, 0, 0) {
+		t.Errorf("%v on %v is %s, expected Ace high straight", pocket, board, DescribeHigh(strength))
+	}
+
+	// four low cards on board with one low hole card is no low
+	board = readCards(t, "Ac2d3h4sKd")
+	pocket = readCards(t, "5cKcQdJs")
+	if strength := omaha.LoStrength(board, pocket); strength.Rank() != NoHand {
+		t.Errorf("%v on %v is %s, expected no low", pocket, board, DescribeLow(strength))
+	}
+	if strength := GetOmahaLowHandStrength(board, pocket); strength.Rank() != NoHand {
+		t.Errorf("%v on %v is %s, expected no low", pocket, board, DescribeLow(strength))
+	}
+
+	// the low uses the best two hole cards with three board cards
+	pocket = readCards(t, "5c6cQdJs")
+	if strength := omaha.LoStrength(board, pocket); strength != MakeHandStrength(HighCard, 0, 0, 0x0037) {
+		t.Errorf("%v on %v is %s, expected Six-Five low", pocket, board, DescribeLow(strength))
+	}
+
+	for itr := Combinations(NewDeck()[:9], 4); itr.HasNext(); {
+		pocket := itr.Next()
+		if omaha.HiStrength(board, pocket) != omaha.HiHand(board, pocket).Strength {
+			t.Errorf("%v on %v strength does not match best hand", pocket, board)
+		}
+		if omaha.LoStrength(board, pocket) != omaha.LoHand(board, pocket).Strength {
+			t.Errorf("%v on %v low strength does not match best hand", pocket, board)
+		}
+	}
+}
+
+func TestEightOrBetter(t *testing.T) {
+	if strength := GetLowHandStrength(readCards(t, "9s7d3hAs2c"), true); strength.Rank() != NoHand {
+		t.Errorf("nine low qualified for eight or better - %#X", strength)
+	}
+	if strength := GetLowHandStrength(readCards(t, "8s7d3hAs2c"), true); strength != MakeHandStrength(HighCard, 0, 0, 0x00C7) {
+		t.Errorf("eight low did not qualify - %#X", strength)
+	}
+	if strength := AceToFiveLow8.Evaluate(readCards(t, "9s7d3hAs2cKdKh")); strength.Rank() != NoHand {
+		t.Errorf("nine low qualified for eight or better - %#X", strength)
+	}
+	board := readCards(t, "9s7d3hKdQc")
+	if strength := GetGame(OmahaHiLo).LoStrength(board, readCards(t, "As2cJhJs")); strength.Rank() != NoHand {
+		t.Errorf("nine low qualified in Omaha Hi/Lo - %#X", strength)
+	}
+}
+
+func TestRegisterGame(t *testing.T) {
+	variant := GetGame(Holdem)
+	variant.Name = "Test Hold'em"
+	if err := RegisterGame("testholdem", variant); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		gamesMu.Lock()
+		delete(games, "testholdem")
+		gamesMu.Unlock()
+	}()
+	if err := RegisterGame("testholdem", variant); err == nil {
+		t.Errorf("registered a game twice")
+	}
+	if game, err := LookupGame("testholdem"); err != nil || game.Name != variant.Name {
+		t.Errorf("lookup registered game %+v - %v", game, err)
+	}
+
+	found := false
+	for _, gt := range Games() {
+		found = found || gt == "testholdem"
+	}
+	if !found {
+		t.Errorf("registered game not listed - %v", Games())
+	}
+
+	if _, err := LookupGame("nosuchgame"); err == nil {
+		t.Errorf("looked up an unknown game")
+	}
+	if game := GetGame("nosuchgame"); game.Name != "" {
+		t.Errorf("unknown game %+v", game)
+	}
+
+	invalid := GetGame(Holdem)
+	invalid.HandSize = 3
+	if err := RegisterGame("testinvalid", invalid); err == nil {
+		t.Errorf("registered a game that deals 2 cards with a hand size of 3")
+	}
+	invalid = GetGame(Holdem)
+	invalid.Hi = nil
+	if err := RegisterGame("testinvalid", invalid); err == nil {
+		t.Errorf("registered a game without an evaluator")
+	}
+	if err := RegisterGame("", GetGame(Holdem)); err == nil {
+		t.Errorf("registered a game without a type")
+	}
+}
+
+func TestGameValidate(t *testing.T) {
+	holdem := GetGame(Holdem)
+	for _, test := range []struct {
+		board string
+		hands []string
+		valid bool
+	}{
+		{"", []string{"AsKs", "QdQh"}, true},
+		{"2c3c4c", []string{"AsKs", "Qd"}, true},
+		{"2c3c4c5c", []string{"AsKs"}, true},
+		{"2c3c4c5c6c", []string{"AsKs"}, true},
+		{"2c3c", []string{"AsKs"}, false},
+		{"2c3c4c5c6c7c", []string{"AsKs"}, false},
+		{"2c3c4c", []string{"AsKsQs"}, false},
+		{"2c3c4c", []string{"As2c"}, false},
+		{"", []string{"AsKs", "KsQs"}, false},
+	} {
+		hands := make([][]Card, len(test.hands))
+		for i, hand := range test.hands {
+			hands[i] = readCards(t, hand)
+		}
+		if err := holdem.Validate(readCards(t, test.board), hands...); (err == nil) != test.valid {
+			t.Errorf("board %s hands %v valid %v - %v", test.board, test.hands, test.valid, err)
+		}
+	}
+
+	if err := GetGame(ShortDeck).Validate(nil, readCards(t, "As5s")); err == nil {
+		t.Errorf("validated a five in short deck")
+	}
+	if err := GetGame(Stud7).Validate(nil, readCards(t, "As5s7d8d9dTdJd")); err != nil {
+		t.Errorf("7 card stud hand - %v", err)
+	}
+}
+
+func TestDiscardGames(t *testing.T) {
+	board := readCards(t, "Ah7c2hKd9s")
+	for _, gt := range []GameType{Pineapple, CrazyPineapple} {
+		game := GetGame(gt)
+		if game.KeptCards() != 2 {
+			t.Errorf("%s keeps %d cards", gt, game.KeptCards())
+		}
+
+		// until discarding, the hand keeps its best two cards on the board
+		// dealt when it discards
+		pocket := readCards(t, "AsKs7d")
+		kept := readCards(t, "AsKs")
+		if gt == CrazyPineapple {
+			kept = readCards(t, "As7d")
+		}
+		if strength := game.HiStrength(board, pocket); strength != GetHoldemHandStrength(board, kept) {
+			t.Errorf("%s %v on %v is %s", gt, pocket, board, DescribeHigh(strength))
+		}
+		if made := game.HiHand(board, pocket); made.Strength != game.HiStrength(board, pocket) || len(made.Pocket) != 2 {
+			t.Errorf("%s %v on %v made %v", gt, pocket, board, made.Cards)
+		}
+
+		// a full house needs all three pocket cards
+		pocket = readCards(t, "AsAd7d")
+		if strength := game.HiStrength(board, pocket); strength.Rank() != Trips {
+			t.Errorf("%s %v on %v is %s", gt, pocket, board, DescribeHigh(strength))
+		}
+	}
+
+	// the discard can't see the cards that come after it
+	pocket := readCards(t, "AhKd7c")
+	board = readCards(t, "7s7h2d3c4c")
+	if strength := GetGame(Pineapple).HiStrength(board, pocket); strength != GetHoldemHandStrength(board, readCards(t, "AhKd")) {
+		t.Errorf("pineapple %v on %v is %s", pocket, board, DescribeHigh(strength))
+	}
+	if strength := GetGame(CrazyPineapple).HiStrength(board, pocket); strength.Rank() != Trips {
+		t.Errorf("crazy pineapple %v on %v is %s", pocket, board, DescribeHigh(strength))
+	}
+
+	if err := GetGame(CrazyPineapple).Validate(readCards(t, "Ah7c2h"), readCards(t, "AsKs7d"), readCards(t, "QhQd")); err != nil {
+		t.Errorf("crazy pineapple after the flop - %v", err)
+	}
+}
+
+func TestCourchevel(t *testing.T) {
+	game := GetGame(Courchevel)
+	if sizes := game.Deal.BoardSizes(); !reflect.DeepEqual(sizes, []int{0, 1, 3, 4, 5}) {
+		t.Errorf("courchevel board sizes %v", sizes)
+	}
+	if err := game.Validate(readCards(t, "Ah7c"), readCards(t, "AsKs7d8d9c")); err == nil {
+		t.Errorf("validated a courchevel board of 2 cards")
+	}
+
+	dealer := game.NewDealer(2, NewDeck())
+	if err := dealer.Deal(); err != nil || len(dealer.Board()) != 1 || len(dealer.Hand(1)) != 5 {
+		t.Errorf("courchevel first round board %v hand %v - %v", dealer.Board(), dealer.Hand(1), err)
+	}
+
+	board := readCards(t, "AhKh2h7c9s")
+	pocket := readCards(t, "QhJc3d4s8d")
+	if strength := game.HiStrength(board, pocket); strength.Rank() != HighCard {
+		t.Errorf("%v on %v is %s", pocket, board, DescribeHigh(strength))
+	}
+	if strength := GetGame(CourchevelHiLo).LoStrength(board, pocket); strength != MakeHandStrength(HighCard, 0, 0, 0x004F) {
+		t.Errorf("%v on %v low is %s", pocket, board, DescribeLow(strength))
+	}
+}
+
+func TestOmahaTable(t *testing.T) {
+	// wrapping the high evaluator skips the lookup table path
+	generic := struct{ Evaluator }{High}
+	deck := NewDeck()
+	source := NewSource(44)
+	for i := 0; i < 2000; i++ {
+		cards := SampleWith(deck, 11, source)
+		board, pocket := cards[:3+i%3], cards[5:5+4+i%3]
+		for pocketCards := 2; pocketCards <= 3; pocketCards++ {
+			strength := omahaStrength(High, board, pocket, pocketCards)
+			if expected := omahaStrength(generic, board, pocket, pocketCards); strength != expected {
+				t.Fatalf("%v on %v is %s, expected %s", pocket, board, DescribeHigh(strength), DescribeHigh(expected))
+			}
+		}
+	}
+}
+
+func TestBigOmaha(t *testing.T) {
+	board := readCards(t, "AhKh7h2h9s")
+	for _, test := range []struct {
+		gt     GameType
+		pocket string
+		hi     HandRank
+	}{
+		{Omaha5, "QhJhJdTs3c", Flush},
+		{Omaha5HiLo, "QcJcJdTs3c", Pair},
+		{BigO, "9c9dJc4s3c", Trips},
+		{Omaha6, "QcJcJdTs9dKs", TwoPair},
+		{Omaha6HiLo, "QhJcJdTsTd3h", Flush},
+	} {
+		game := GetGame(test.gt)
+		pocket := readCards(t, test.pocket)
+		if err := game.Validate(board, pocket); err != nil {
+			t.Errorf("%s %v", test.gt, err)
+		}
+		if strength := game.HiStrength(board, pocket); strength.Rank() != test.hi {
+			t.Errorf("%s %v on %v is %s, expected %s", test.gt, pocket, board, DescribeHigh(strength), test.hi)
+		}
+	}
+
+	// Big O low still plays exactly two hole cards
+	bigO := GetGame(BigO)
+	pocket := readCards(t, "3c4dKcKdQs")
+	if strength := bigO.LoStrength(board, pocket); bigO.Lo.Describe(strength) != "Seven-Four low" {
+		t.Errorf("%v on %v is %s, expected Seven-Four low", pocket, board, bigO.Lo.Describe(strength))
+	}
+	pocket = readCards(t, "3c9dKcKdQs")
+	if strength := bigO.LoStrength(board, pocket); strength.Rank() != NoHand {
+		t.Errorf("%v on %v is %s, expected no low", pocket, board, bigO.Lo.Describe(strength))
+	}
+}
@@ -0,0 +1,79 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+)
+
+// MadeHand is the best hand a player can make and the cards it uses.
+type MadeHand struct {
+	Strength HandStrength
+	Cards    []Card
+	Pocket   []Card
+	Board    []Card
+}
+
+func (mh MadeHand) IsQualified() bool {
+	return mh.Strength.Rank() != NoHand
+}
+
+// BestHand returns the best hand from pocket and board according to e. If
+// pocketCards is positive the hand must use exactly that many pocket cards
+// and the rest from the board, as in Omaha.
+func BestHand(e Evaluator, board, pocket []Card, pocketCards int) MadeHand {
+	if pocketCards == 0 {
+		hand := make([]Card, 0, len(pocket)+len(board))
+		hand = append(hand, pocket...)
+		hand = append(hand, board...)
+		cards, strength := e.BestFive(hand)
+		made := MadeHand{Strength: strength, Cards: cards}
+		for _, card := range cards {
+			if Index(pocket, card) >= 0 {
+				made.Pocket = append(made.Pocket, card)
+			} else {
+				made.Board = append(made.Board, card)
+			}
+		}
+		return made
+	}
+
+	best := MadeHand{Strength: MakeHandStrength(NoHand, 0, 0, 0)}
+	boardCards := 5 - pocketCards
+	if boardCards > len(board) {
+		boardCards = len(board)
+	}
+	if pocketCards > len(pocket) {
+		return best
+	}
+
+	hand := make([]Card, pocketCards+boardCards)
+	for pocketItr := Combinations(pocket, pocketCards); pocketItr.HasNext(); {
+		fromPocket := pocketItr.Next()
+		copy(hand, fromPocket)
+		for boardItr := Combinations(board, boardCards); boardItr.HasNext(); {
+			fromBoard := boardItr.Next()
+			copy(hand[pocketCards:], fromBoard)
+			strength := e.Evaluate(hand)
+			if strength.Rank() != NoHand && (best.Cards == nil || e.Compare(strength, best.Strength) > 0) {
+				best = MadeHand{
+					Strength: strength,
+					Cards:    append([]Card{}, hand...),
+					Pocket:   fromPocket,
+					Board:    fromBoard,
+				}
+			}
+		}
+	}
+	return best
+}
+
+func (g Game) HiHand(board, pocket []Card) MadeHand {
+	return g.bestHand(g.Hi, board, pocket)
+}
+
+func (g Game) LoHand(board, pocket []Card) MadeHand {
+	return g.bestHand(g.Lo, board, pocket)
+}
+
+func (g Game) bestHand(e Evaluator, board, pocket []Card) MadeHand {
+	return BestHand(e, board, g.keep(board, pocket), g.PocketCards)
+}
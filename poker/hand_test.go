@@ -0,0 +1,72 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"strings"
+	"testing"
+)
+
+func readCards(t *testing.T, s string) []Card {
+	cards, err := NewCardReader(strings.NewReader(s)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cards
+}
+
+func TestHoldemHand(t *testing.T) {
+	board := readCards(t, "AhKh2c7d9s")
+	pocket := readCards(t, "As3c")
+
+	made := GetGame(Holdem).HiHand(board, pocket)
+	assertCards(t, made.Cards, readCards(t, "AsAhKh9s7d"))
+	assertCards(t, made.Pocket, readCards(t, "As"))
+	assertCards(t, made.Board, readCards(t, "AhKh9s7d"))
+	if made.Strength != GetHoldemHandStrength(board, pocket) {
+		t.Errorf("strength %#X", made.Strength)
+	}
+}
+
+func TestStudLowHand(t *testing.T) {
+	pocket := readCards(t, "KsAs2h4d8c8dQh")
+
+	made := GetGame(Stud7HiLo).LoHand(nil, pocket)
+	if made.IsQualified() || made.Cards != nil {
+		t.Errorf("qualified low %v", made.Cards)
+	}
+
+	pocket = readCards(t, "KsAs2h4d8c7dQh")
+	made = GetGame(Stud7HiLo).LoHand(nil, pocket)
+	assertCards(t, made.Cards, readCards(t, "As2h4d8c7d"))
+	if !made.IsQualified() || made.Strength != GetLowHandStrength(pocket, true) {
+		t.Errorf("low strength %#X", made.Strength)
+	}
+}
+
+func TestOmahaHand(t *testing.T) {
+	board := readCards(t, "AhKh2h7h9s")
+	pocket := readCards(t, "QhJcJdTs")
+
+	made := GetGame(Omaha).HiHand(board, pocket)
+	assertCards(t, made.Pocket, readCards(t, "JcJd"))
+	assertCards(t, made.Board, readCards(t, "AhKh9s"))
+	if made.Strength.Rank() != Pair {
+		t.Errorf("expected a pair using two hole cards, got %s", made.Strength.Rank())
+	}
+
+	pocket = readCards(t, "3c4dQhJc")
+	made = GetGame(OmahaHiLo).LoHand(board, pocket)
+	assertCards(t, made.Pocket, readCards(t, "3c4d"))
+	assertCards(t, made.Board, readCards(t, "Ah2h7h"))
+
+	made = GetGame(OmahaHiLo).LoHand(board, readCards(t, "QhJcJdTs"))
+	if made.IsQualified() || made.Cards != nil {
+		t.Errorf("qualified low %v", made.Cards)
+	}
+}
+
+func assertCards(t *testing.T, actual, expect []Card) {
+	if len(actual) != len(expect) || Mask(actual) != Mask(expect) {
+		t.Errorf("cards %v, expected %v", actual, expect)
+	}
+}
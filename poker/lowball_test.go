@@ -0,0 +1,73 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"testing"
+)
+
+func TestDeuceToSevenHands(t *testing.T) {
+	counts := make(map[HandRank]int)
+	for itr := Combinations(NewDeck(), 5); itr.HasNext(); {
+		counts[DeuceToSeven.Evaluate(itr.Next()).Rank()]++
+	}
+
+	expected := map[HandRank]int{
+		StraightFlush: 36,
+		Quads:         624,
+		FullHouse:     3744,
+		Flush:         5112,
+		Straight:      9180,
+		Trips:         54912,
+		TwoPair:       123552,
+		Pair:          1098240,
+		HighCard:      1303560,
+	}
+	for rank, count := range expected {
+		if counts[rank] != count {
+			t.Errorf("%d %s hands, expected %d", counts[rank], rank, count)
+		}
+	}
+}
+
+func TestDeuceToSeven(t *testing.T) {
+	order := []string{
+		"7s5d4h3c2s",
+		"7s6d4h3c2s",
+		"8s6d4h3c2s",
+		"KsQdJhTc8s",
+		"As5d4h3c2s",
+		"AsKdQhJc9s",
+		"2s2d4h3c5s",
+		"7s6d5h4c3s",
+		"7h5h4h3h2h",
+	}
+
+	strengths := make([]HandStrength, len(order))
+	for i, s := range order {
+		strengths[i] = DeuceToSeven.Evaluate(readCards(t, s))
+	}
+	for i := 1; i < len(strengths); i++ {
+		if DeuceToSeven.Compare(strengths[i-1], strengths[i]) <= 0 || DeuceToSeven.Compare(strengths[i], strengths[i-1]) >= 0 {
+			t.Errorf("%s does not beat %s", order[i-1], order[i])
+		}
+	}
+
+	if strength := DeuceToSeven.Evaluate(readCards(t, "7s5d4h3c2sKsKd")); strength != strengths[0] {
+		t.Errorf("best 2-7 hand from 7 cards is %s", DescribeDeuceToSeven(strength))
+	}
+	if strength := strengths[4]; strength.Rank() != HighCard {
+		t.Errorf("A-2-3-4-5 is %s", DescribeDeuceToSeven(strength))
+	}
+	if strength := DeuceToSeven.Evaluate(readCards(t, "AhKhQhJhTh")); strength.Rank() != StraightFlush {
+		t.Errorf("royal flush is %s", DescribeDeuceToSeven(strength))
+	}
+}
+
+func TestDeuceToSevenGames(t *testing.T) {
+	for gt, draws := range map[GameType]int{DeuceToSevenSingle: 1, DeuceToSevenTriple: 3} {
+		game := GetGame(gt)
+		if game.Draws != draws || game.HandSize != 5 || game.BoardSize != 0 || game.HasHiHand() || game.Lo != DeuceToSeven {
+			t.Errorf("%s %+v", gt, game)
+		}
+	}
+}
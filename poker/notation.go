@@ -0,0 +1,543 @@
+package poker
+
+import (
+	"fmt"
+	. "github.com/dohodges/fifty2"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseRange parses a Hold'em range in the notation of most poker tools,
+// such as "AKs, TT+, A2s-A5s, KQo, 76s+, AhKh, JJ-88:0.5". Terms are:
+//
+//	AA, AKs, AKo, AK    a pair, suited, offsuit or any two cards
+//	TT+                 a pair and every higher pair
+//	A2s+                raising the kicker up to one below the top card
+//	76s+                connectors, raising both cards up to AKs
+//	JJ-88, A2s-A5s      every hand between two of the same shape
+//	AhKh                one holding
+//
+// A term ending with ":weight" is played that often. A holding in more than
+// one term takes the weight of the last. Holdings with any of the dead cards,
+// such as the board, are removed.
+func ParseRange(s string, dead ...Card) (Range, error) {
+	return parseRange(s, parseHoldemTerm, dead)
+}
+
+// ParseOmahaRange parses an Omaha range of hands given card by card, such as
+// "AAxx, A♠K♠xx, KhKdxx:0.5". Each card is a rank, a card, x for any card or
+// x and a suit for any card of the suit. Hands have as many cards as given,
+// four to six, for five and six card Omaha. Weights and dead cards are as
+// for ParseRange.
+func ParseOmahaRange(s string, dead ...Card) (Range, error) {
+	return parseRange(s, parseOmahaTerm, dead)
+}
+
+func parseRange(s string, parseTerm func(string) ([][]Card, error), dead []Card) (Range, error) {
+	r := make(Range, 0)
+	index := make(map[uint64]int)
+	for _, term := range strings.Split(s, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		weight := 1.
+		if i := strings.LastIndex(term, ":"); i >= 0 {
+			w, err := strconv.ParseFloat(term[i+1:], 64)
+			if err != nil || !(w > 0) {
+				return nil, fmt.Errorf("fifty2/poker: invalid weight in range term[%s]", term)
+			}
+			term, weight = term[:i], w
+		}
+
+		holdings, err := parseTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		for _, cards := range holdings {
+			mask := Mask(cards)
+			if i, ok := index[mask]; ok {
+				r[i].Weight = weight
+				continue
+			}
+			index[mask] = len(r)
+			r = append(r, Combo{cards, weight})
+		}
+	}
+	return r.Without(dead...), nil
+}
+
+// holdemClass is a Hold'em starting hand without suits, such as AKs. suited
+// is 's', 'o' or 0 for any suits.
+type holdemClass struct {
+	hi, lo CardStrength
+	suited byte
+}
+
+func strengthOf(rank Rank) CardStrength {
+	if rank == Ace {
+		return AceHigh
+	}
+	return CardStrength(rank)
+}
+
+func parseHoldemTerm(term string) ([][]Card, error) {
+	// a single holding
+	if cards, err := NewCardReader(strings.NewReader(term)).ReadAll(); err == nil {
+		if len(cards) != 2 || cards[0] == cards[1] || cards[0].Rank == Joker || cards[1].Rank == Joker {
+			return nil, fmt.Errorf("fifty2/poker: invalid Hold'em hand in range term[%s]", term)
+		}
+		return [][]Card{orderCards(cards)}, nil
+	}
+
+	var classes []holdemClass
+	switch {
+	case strings.HasSuffix(term, "+"):
+		class, err := parseHoldemClass(term[:len(term)-1])
+		if err != nil {
+			return nil, err
+		}
+		top := class
+		switch {
+		case class.hi == class.lo:
+			top.hi, top.lo = AceHigh, AceHigh
+		case class.hi-class.lo == 1:
+			top.hi, top.lo = AceHigh, AceHigh-1
+		default:
+			top.lo = class.hi - 1
+		}
+		classes, err = holdemClasses(class, top)
+		if err != nil {
+			return nil, err
+		}
+	case strings.Contains(term, "-"):
+		ends := strings.Split(term, "-")
+		if len(ends) != 2 {
+			return nil, fmt.Errorf("fifty2/poker: invalid range term[%s]", term)
+		}
+		a, err := parseHoldemClass(ends[0])
+		if err != nil {
+			return nil, err
+		}
+		b, err := parseHoldemClass(ends[1])
+		if err != nil {
+			return nil, err
+		}
+		if classes, err = holdemClasses(a, b); err != nil {
+			return nil, fmt.Errorf("fifty2/poker: %v in range term[%s]", err, term)
+		}
+	default:
+		class, err := parseHoldemClass(term)
+		if err != nil {
+			return nil, err
+		}
+		classes = []holdemClass{class}
+	}
+
+	holdings := make([][]Card, 0, 16*len(classes))
+	for _, class := range classes {
+		holdings = append(holdings, class.holdings()...)
+	}
+	return holdings, nil
+}
+
+func parseHoldemClass(s string) (holdemClass, error) {
+	runes := []rune(s)
+	if len(runes) < 2 || len(runes) > 3 {
+		return holdemClass{}, fmt.Errorf("fifty2/poker: invalid Hold'em hand[%s]", s)
+	}
+	var class holdemClass
+	for i, strength := range []*CardStrength{&class.hi, &class.lo} {
+		rank, err := ParseRank(runes[i])
+		if err != nil || rank == Joker {
+			return holdemClass{}, fmt.Errorf("fifty2/poker: invalid Hold'em hand[%s]", s)
+		}
+		*strength = strengthOf(rank)
+	}
+	if class.lo > class.hi {
+		class.hi, class.lo = class.lo, class.hi
+	}
+	if len(runes) == 3 {
+		switch runes[2] {
+		case 's', 'S':
+			class.suited = 's'
+		case 'o', 'O':
+			class.suited = 'o'
+		default:
+			return holdemClass{}, fmt.Errorf("fifty2/poker: invalid Hold'em hand[%s]", s)
+		}
+	}
+	if class.hi == class.lo && class.suited != 0 {
+		return holdemClass{}, fmt.Errorf("fifty2/poker: pair can't be suited or offsuit[%s]", s)
+	}
+	return class, nil
+}
+
+// holdemClasses returns the classes from a to b, which must both be pairs,
+// have the same top card or the same gap.
+func holdemClasses(a, b holdemClass) ([]holdemClass, error) {
+	if a.suited != b.suited || (a.hi == a.lo) != (b.hi == b.lo) {
+		return nil, fmt.Errorf("hands of different shapes")
+	}
+	if a.hi > b.hi || (a.hi == b.hi && a.lo > b.lo) {
+		a, b = b, a
+	}
+
+	var classes []holdemClass
+	switch {
+	case a.hi == b.hi:
+		for lo := a.lo; lo <= b.lo; lo++ {
+			classes = append(classes, holdemClass{a.hi, lo, a.suited})
+		}
+	case a.hi-a.lo == b.hi-b.lo:
+		for hi, lo := a.hi, a.lo; hi <= b.hi; hi, lo = hi+1, lo+1 {
+			classes = append(classes, holdemClass{hi, lo, a.suited})
+		}
+	default:
+		return nil, fmt.Errorf("hands without the same top card or gap")
+	}
+	return classes, nil
+}
+
+func (hc holdemClass) holdings() [][]Card {
+	holdings := make([][]Card, 0, 16)
+	for _, hiSuit := range Suits() {
+		for _, loSuit := range Suits() {
+			switch {
+			case hc.hi == hc.lo && loSuit >= hiSuit:
+			case hc.suited == 's' && loSuit != hiSuit:
+			case hc.suited == 'o' && loSuit == hiSuit:
+			default:
+				holdings = append(holdings, orderCards([]Card{{hc.hi.Rank(), hiSuit}, {hc.lo.Rank(), loSuit}}))
+			}
+		}
+	}
+	return holdings
+}
+
+func (hc holdemClass) String() string {
+	s := string([]rune{hc.hi.Rune(), hc.lo.Rune()})
+	if hc.suited != 0 {
+		s += string(hc.suited)
+	}
+	return s
+}
+
+// orderCards sorts cards from the highest rank down, aces high, and by suit
+// from spades down.
+func orderCards(cards []Card) []Card {
+	sort.Slice(cards, func(i, j int) bool {
+		if a, b := strengthOf(cards[i].Rank), strengthOf(cards[j].Rank); a != b {
+			return a > b
+		}
+		return cards[i].Suit > cards[j].Suit
+	})
+	return cards
+}
+
+// omahaCard is one card of an Omaha range term. A nil rank or suit is any.
+type omahaCard struct {
+	rank *Rank
+	suit *Suit
+}
+
+func (oc omahaCard) matches(card Card) bool {
+	return (oc.rank == nil || *oc.rank == card.Rank) && (oc.suit == nil || *oc.suit == card.Suit)
+}
+
+func parseOmahaTerm(term string) ([][]Card, error) {
+	runes := []rune(term)
+	pattern := make([]omahaCard, 0, 6)
+	for i := 0; i < len(runes); i++ {
+		var oc omahaCard
+		if runes[i] != 'x' && runes[i] != 'X' {
+			rank, err := ParseRank(runes[i])
+			if err != nil || rank == Joker {
+				return nil, fmt.Errorf("fifty2/poker: invalid Omaha hand[%s]", term)
+			}
+			oc.rank = &rank
+		}
+		if i+1 < len(runes) {
+			if suit, err := ParseSuit(runes[i+1]); err == nil {
+				oc.suit = &suit
+				i++
+			}
+		}
+		pattern = append(pattern, oc)
+	}
+	if len(pattern) < 4 || len(pattern) > 6 {
+		return nil, fmt.Errorf("fifty2/poker: Omaha hand[%s] has %d cards, expected 4 to 6", term, len(pattern))
+	}
+	for i, oc := range pattern {
+		for _, other := range pattern[:i] {
+			if oc.rank != nil && oc.suit != nil && sameOmahaCard(oc, other) {
+				return nil, fmt.Errorf("fifty2/poker: Omaha hand[%s] has %s more than once", term, Card{*oc.rank, *oc.suit})
+			}
+		}
+	}
+
+	// the cards matching each pattern card, with identical pattern cards
+	// taking their cards in deck order so each hand is found once per order
+	deck := NewDeck()
+	holdings := make([][]Card, 0)
+	seen := make(map[uint64]bool)
+	hand := make([]Card, len(pattern))
+	var fill func(i, from int, mask uint64)
+	fill = func(i, from int, mask uint64) {
+		if i == len(pattern) {
+			if !seen[mask] {
+				seen[mask] = true
+				holdings = append(holdings, orderCards(append([]Card{}, hand...)))
+			}
+			return
+		}
+		if i == 0 || !sameOmahaCard(pattern[i], pattern[i-1]) {
+			from = 0
+		}
+		for j := from; j < len(deck); j++ {
+			if card := deck[j]; mask&card.Mask() == 0 && pattern[i].matches(card) {
+				hand[i] = card
+				fill(i+1, j+1, mask|card.Mask())
+			}
+		}
+	}
+	sort.SliceStable(pattern, func(i, j int) bool { return pattern[i].key() < pattern[j].key() })
+	fill(0, 0, 0)
+	if len(holdings) == 0 {
+		return nil, fmt.Errorf("fifty2/poker: Omaha hand[%s] matches no holding", term)
+	}
+	return holdings, nil
+}
+
+func (oc omahaCard) key() int {
+	key := 0
+	if oc.rank != nil {
+		key += 1 + int(*oc.rank)
+	}
+	if oc.suit != nil {
+		key += 16 * (1 + int(*oc.suit))
+	}
+	return key
+}
+
+func sameOmahaCard(a, b omahaCard) bool {
+	return a.key() == b.key()
+}
+
+// String returns the range in canonical notation, which ParseRange or
+// ParseOmahaRange reads back as the same range. Hold'em ranges are written as
+// the hands of ParseRange, joined into runs of pairs, kickers, connectors and
+// gappers, longest first. Other ranges are written card by card.
+func (r Range) String() string {
+	terms := make([]string, 0)
+	holdem := len(r) > 0
+	for _, combo := range r {
+		holdem = holdem && len(combo.Cards) == 2
+	}
+	if holdem {
+		terms = r.holdemTerms()
+	} else {
+		for _, combo := range r.sorted() {
+			terms = append(terms, withWeight(cardsString(combo.Cards), combo.Weight))
+		}
+	}
+	return strings.Join(terms, ", ")
+}
+
+func withWeight(term string, weight float64) string {
+	if weight == 1 {
+		return term
+	}
+	return term + ":" + strconv.FormatFloat(weight, 'g', -1, 64)
+}
+
+func cardsString(cards []Card) string {
+	s := ""
+	for _, card := range orderCards(append([]Card{}, cards...)) {
+		s += card.String()
+	}
+	return s
+}
+
+// sorted returns the combos ordered by their cards, highest first.
+func (r Range) sorted() Range {
+	sorted := make(Range, len(r))
+	for i, combo := range r {
+		sorted[i] = Combo{orderCards(append([]Card{}, combo.Cards...)), combo.Weight}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Cards, sorted[j].Cards
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				if sa, sb := strengthOf(a[k].Rank), strengthOf(b[k].Rank); sa != sb {
+					return sa > sb
+				}
+				return a[k].Suit > b[k].Suit
+			}
+		}
+		return len(a) < len(b)
+	})
+	return sorted
+}
+
+func (r Range) holdemTerms() []string {
+	weights := make(map[uint64]float64)
+	for _, combo := range r {
+		weights[Mask(combo.Cards)] = combo.Weight
+	}
+
+	// the classes whose holdings are all in the range with one weight, any
+	// suits before suited and offsuit, removing their holdings from weights
+	classes := make(map[holdemClass]float64)
+	for hi := AceHigh; hi > AceLow; hi-- {
+		for lo := hi; lo > AceLow; lo-- {
+			for _, suited := range []byte{0, 's', 'o'} {
+				class := holdemClass{hi, lo, suited}
+				if hi == lo && suited != 0 {
+					continue
+				}
+				if weight, ok := fullClassWeight(weights, class); ok {
+					classes[class] = weight
+				}
+			}
+		}
+	}
+
+	// the longest run left, down the kickers or up the board, until only
+	// single classes are left
+	runs := make([]classRun, 0)
+	for len(classes) > 0 {
+		var best classRun
+		for _, class := range sortedClasses(classes) {
+			for _, step := range []func(holdemClass) holdemClass{holdemClass.nextKicker, holdemClass.nextUp} {
+				if run := findRun(classes, class, step); run.n > best.n {
+					best = run
+				}
+			}
+		}
+		for class, n := best.from, 0; n < best.n; class, n = best.step(class), n+1 {
+			delete(classes, class)
+		}
+		runs = append(runs, best)
+	}
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].to.before(runs[j].to) })
+
+	terms := make([]string, 0, len(runs))
+	for _, run := range runs {
+		terms = append(terms, withWeight(run.String(), run.weight))
+	}
+
+	// single holdings that make no class
+	rest := make(Range, 0, len(weights))
+	for _, combo := range r {
+		if weight, ok := weights[Mask(combo.Cards)]; ok {
+			rest = append(rest, Combo{combo.Cards, weight})
+			delete(weights, Mask(combo.Cards))
+		}
+	}
+	for _, combo := range rest.sorted() {
+		terms = append(terms, withWeight(cardsString(combo.Cards), combo.Weight))
+	}
+	return terms
+}
+
+// fullClassWeight returns the weight of a class whose holdings are all in
+// weights with the same weight, and removes them.
+func fullClassWeight(weights map[uint64]float64, class holdemClass) (float64, bool) {
+	holdings := class.holdings()
+	weight, ok := weights[Mask(holdings[0])]
+	for _, cards := range holdings {
+		if w, found := weights[Mask(cards)]; !found || w != weight {
+			return 0, false
+		}
+	}
+	for _, cards := range holdings {
+		delete(weights, Mask(cards))
+	}
+	return weight, ok
+}
+
+// classRun is n classes of one weight from the lowest, from, to the highest,
+// to, each the step of the one before.
+type classRun struct {
+	from, to holdemClass
+	n        int
+	weight   float64
+	step     func(holdemClass) holdemClass
+}
+
+// findRun returns the run of classes with the weight of from, starting at
+// from unless the class before it is in the run.
+func findRun(classes map[holdemClass]float64, from holdemClass, step func(holdemClass) holdemClass) classRun {
+	weight := classes[from]
+	for class, w := range classes {
+		if w == weight && step(class) == from {
+			return classRun{}
+		}
+	}
+	run := classRun{from: from, to: from, n: 1, weight: weight, step: step}
+	for next := step(from); ; next = step(next) {
+		if w, ok := classes[next]; !ok || w != weight {
+			return run
+		}
+		run.to = next
+		run.n++
+	}
+}
+
+func (cr classRun) String() string {
+	switch {
+	case cr.n == 1:
+		return cr.from.String()
+	case cr.from.hi == cr.to.hi && cr.to.lo == cr.to.hi-1:
+		// kickers up to one below the top card
+		return cr.from.String() + "+"
+	case cr.from.hi != cr.to.hi && cr.to.hi == AceHigh && cr.to.hi-cr.to.lo <= 1:
+		// pairs up to aces or connectors up to AK
+		return cr.from.String() + "+"
+	}
+	return cr.to.String() + "-" + cr.from.String()
+}
+
+// nextKicker returns the class with the next higher kicker, which is never
+// a pair.
+func (hc holdemClass) nextKicker() holdemClass {
+	if hc.hi == hc.lo || hc.lo+1 >= hc.hi {
+		return holdemClass{}
+	}
+	return holdemClass{hc.hi, hc.lo + 1, hc.suited}
+}
+
+// nextUp returns the class with both cards one higher.
+func (hc holdemClass) nextUp() holdemClass {
+	if hc.hi >= AceHigh {
+		return holdemClass{}
+	}
+	return holdemClass{hc.hi + 1, hc.lo + 1, hc.suited}
+}
+
+// before orders classes as they're written, pairs first and then from the
+// highest top card, any suits before suited and offsuit, and the highest
+// kicker down.
+func (hc holdemClass) before(other holdemClass) bool {
+	switch {
+	case (hc.hi == hc.lo) != (other.hi == other.lo):
+		return hc.hi == hc.lo
+	case hc.hi != other.hi:
+		return hc.hi > other.hi
+	case hc.suited != other.suited:
+		return hc.suited == 0 || (hc.suited == 's' && other.suited == 'o')
+	}
+	return hc.lo > other.lo
+}
+
+func sortedClasses(classes map[holdemClass]float64) []holdemClass {
+	sorted := make([]holdemClass, 0, len(classes))
+	for class := range classes {
+		sorted = append(sorted, class)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].before(sorted[j]) })
+	return sorted
+}
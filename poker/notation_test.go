@@ -0,0 +1,186 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	for _, test := range []struct {
+		notation string
+		dead     string
+		combos   int
+		weight   float64
+	}{
+		{"AA", "", 6, 6},
+		{"AKs", "", 4, 4},
+		{"AKo", "", 12, 12},
+		{"KA", "", 16, 16},
+		{"TT+", "", 30, 30},
+		{"JJ-88", "", 24, 24},
+		{"88-JJ", "", 24, 24},
+		{"A2s-A5s", "", 16, 16},
+		{"A2s+", "", 48, 48},
+		{"K9o+", "", 48, 48},
+		{"76s+", "", 32, 32},
+		{"T8s-64s", "", 20, 20},
+		{"AhKh", "", 1, 1},
+		{"A♥K♥, AKs", "", 4, 4},
+		{"AK:0.25", "", 16, 4},
+		{"TT+, JJ-88:0.5", "", 42, 30},
+		{"AKs, TT+, A2s-A5s, KQo, 76s+, AhKh, JJ-88:0.5", "", 102, 90},
+		{"AKs, TT+", "AhTc7d", 27, 27},
+		{"AA:0.5, AsAh", "As", 3, 1.5},
+	} {
+		r, err := ParseRange(test.notation, readCards(t, test.dead)...)
+		if err != nil {
+			t.Errorf("%s: %v", test.notation, err)
+			continue
+		}
+		if len(r) != test.combos || r.Weight() != test.weight {
+			t.Errorf("%s has %d combos weighing %v, expected %d weighing %v", test.notation, len(r), r.Weight(), test.combos, test.weight)
+		}
+		dead := Mask(readCards(t, test.dead))
+		for _, combo := range r {
+			if len(combo.Cards) != 2 || combo.Cards[0] == combo.Cards[1] || Mask(combo.Cards)&dead != 0 {
+				t.Errorf("%s has combo %v", test.notation, combo.Cards)
+			}
+		}
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	for _, notation := range []string{
+		"AKx",
+		"AAs",
+		"A",
+		"AKQ",
+		"AsAs",
+		"AsKsQs",
+		"AKs-QTs",
+		"AKs-AQo",
+		"AKs-88",
+		"AK-QJ-JT",
+		"AK:",
+		"AK:x",
+		"AK:-1",
+		"AK:0",
+		"XX",
+	} {
+		if r, err := ParseRange(notation); err == nil {
+			t.Errorf("%s parsed as %v", notation, r)
+		}
+	}
+}
+
+func TestParseOmahaRange(t *testing.T) {
+	for _, test := range []struct {
+		notation string
+		dead     string
+		combos   int
+	}{
+		{"AAxx", "", 6961},
+		{"AAxx", "As", 3432},
+		{"A♠K♠xx", "", 1225},
+		{"AsKsxx", "Qs", 1176},
+		{"AAKK", "", 36},
+		{"xsxsxsxs", "", 715},
+		{"AAKK, KKAA:0.5", "", 36},
+		{"AAKKx", "", 36*44 + 2*4*6},
+	} {
+		r, err := ParseOmahaRange(test.notation, readCards(t, test.dead)...)
+		if err != nil {
+			t.Errorf("%s: %v", test.notation, err)
+			continue
+		}
+		if len(r) != test.combos {
+			t.Errorf("%s has %d combos, expected %d", test.notation, len(r), test.combos)
+		}
+	}
+
+	r, _ := ParseOmahaRange("AAKK, KKAA:0.5")
+	if r.Weight() != 18 {
+		t.Errorf("AAKK, KKAA:0.5 weighs %v, expected 18", r.Weight())
+	}
+
+	for _, notation := range []string{"AAx", "AAKZ", "AAKK:x", "AsAsxx", "AAAAA", "AhKhQhJhTh9h8h", "xxxxxxx"} {
+		if r, err := ParseOmahaRange(notation); err == nil {
+			t.Errorf("%s parsed as %d combos", notation, len(r))
+		}
+	}
+}
+
+func TestRangeString(t *testing.T) {
+	for _, test := range []struct {
+		notation, canonical string
+	}{
+		{"TT+", "TT+"},
+		{"AA, KK", "KK+"},
+		{"88-JJ", "JJ-88"},
+		{"AKs, AKo", "AK"},
+		{"A2s-A5s", "A5s-A2s"},
+		{"KQs, KJs, KTs", "KTs+"},
+		{"AKs, TT+, A2s-A5s, KQo, 76s+, AhKh, JJ-88:0.5",
+			"QQ+, JJ-88:0.5, AKs, A5s-A2s, KQ, QJs-76s"},
+		{"76s+", "76s+"},
+		{"54o+", "54o+"},
+		{"T8s-64s", "T8s-64s"},
+		{"AQs-KJs, AQo", "AQ, KJs"},
+		{"76s+, A2s+, KQo:0.5", "A2s+, KQs-76s, KQo:0.5"},
+		{"76s+, 65s:0.5", "76s+, 65s:0.5"},
+		{"AhKh, AsKs:0.5, 2c2d", "A♠K♠:0.5, A♥K♥, 2♦2♣"},
+		{"AK:0.5, AsKs", "AKo:0.5, A♠K♠, A♥K♥:0.5, A♦K♦:0.5, A♣K♣:0.5"},
+	} {
+		r, err := ParseRange(test.notation)
+		if err != nil {
+			t.Errorf("%s: %v", test.notation, err)
+			continue
+		}
+		if s := r.String(); s != test.canonical {
+			t.Errorf("%s prints as %s, expected %s", test.notation, s, test.canonical)
+		}
+	}
+
+	omaha, _ := ParseOmahaRange("2c2sKsAs, AAKK:0.5", readCards(t, "AdAcKdKc")...)
+	if s := omaha.String(); s != "A♠A♥K♠K♥:0.5, A♠K♠2♠2♣" {
+		t.Errorf("AAKK prints as %s", s)
+	}
+}
+
+func TestRangeRoundTrip(t *testing.T) {
+	for _, notation := range []string{
+		"AKs, TT+, A2s-A5s, KQo, 76s+, AhKh, JJ-88:0.5",
+		"22+, A2+, K2s+, Q9o+:0.75, 54s:0.1, 7h6h",
+		"AA:0.5, AsAh, KK-QQ:0.25, AK, AQs:0.5, AQo",
+		"72o, 32s+, AcKd:2",
+		"76s+, A2s+, KQo:0.5",
+		"54s+, 64s+, T8o-53o:0.25, 22-55",
+		"J9s+, T8s+, 97s+, 86s+, 75s+",
+	} {
+		r, err := ParseRange(notation)
+		if err != nil {
+			t.Fatalf("%s: %v", notation, err)
+		}
+		s := r.String()
+		parsed, err := ParseRange(s)
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+		if !sameRange(r, parsed) {
+			t.Errorf("%s prints as %s, which parses differently", notation, s)
+		}
+	}
+}
+
+func sameRange(a, b Range) bool {
+	weights := make(map[uint64]float64)
+	for _, combo := range a {
+		weights[Mask(combo.Cards)] = combo.Weight
+	}
+	for _, combo := range b {
+		if w, ok := weights[Mask(combo.Cards)]; !ok || w != combo.Weight {
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
@@ -0,0 +1,53 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+)
+
+var (
+	PartialHigh Evaluator = PartialEvaluator{}
+	PartialLow  Evaluator = PartialEvaluator{Low: true}
+)
+
+// PartialEvaluator ranks partial hands of one to four cards, such as the
+// upcards showing in stud. Only quads, trips, two pair and pairs are made
+// from so few cards, and the other cards are kickers, so a pair of nines
+// showing beats ace-king showing. With Low set aces are low and the lowest
+// cards win, so any unpaired hand beats a pair, as in Razz.
+//
+// The high and ace-to-five low evaluators already rank hands of fewer than
+// five cards this way, so partial hands are evaluated by them and share their
+// strengths.
+type PartialEvaluator struct {
+	Low bool
+}
+
+func (e PartialEvaluator) full() Evaluator {
+	if e.Low {
+		return AceToFiveLow
+	}
+	return High
+}
+
+func (e PartialEvaluator) Evaluate(hand []Card) HandStrength {
+	return e.full().Evaluate(hand)
+}
+
+func (e PartialEvaluator) Compare(a, b HandStrength) int {
+	if e.Low {
+		return compareLow(a, b)
+	}
+	return compareHigh(a, b)
+}
+
+func (e PartialEvaluator) Describe(strength HandStrength) string {
+	return e.full().Describe(strength)
+}
+
+func (e PartialEvaluator) BestFive(hand []Card) ([]Card, HandStrength) {
+	return e.full().BestFive(hand)
+}
+
+func (e PartialEvaluator) Explain(a, b HandStrength) string {
+	return explain(e, a, b, e.Low)
+}
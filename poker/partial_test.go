@@ -0,0 +1,125 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestPartialHands(t *testing.T) {
+	deck := NewDeck()
+	for _, e := range []PartialEvaluator{{}, {Low: true}} {
+		for size := 1; size <= 4; size++ {
+			// every hand with the same rank counts must evaluate the same,
+			// and hands must order as their rank counts do
+			strengths := make(map[string]HandStrength)
+			for itr := Combinations(deck, size); itr.HasNext(); {
+				hand := itr.Next()
+				key := partialKey(hand, e.Low)
+				strength := e.Evaluate(hand)
+				if strength.Rank() != HandRank(key[0]) {
+					t.Fatalf("%v is %s, expected %s", hand, e.Describe(strength), HandRank(key[0]))
+				}
+				if expected, ok := strengths[key]; !ok {
+					strengths[key] = strength
+				} else if e.Compare(strength, expected) != 0 {
+					t.Fatalf("%v is %s, expected %s", hand, e.Describe(strength), e.Describe(expected))
+				}
+			}
+
+			keys := make([]string, 0, len(strengths))
+			for key := range strengths {
+				keys = append(keys, key)
+			}
+			sort.Slice(keys, func(i, j int) bool { return comparePartialKeys(keys[i], keys[j], e.Low) < 0 })
+			for i := 1; i < len(keys); i++ {
+				worse, better := strengths[keys[i-1]], strengths[keys[i]]
+				if e.Compare(better, worse) != 1 {
+					t.Fatalf("%s compared to %s is %d, expected 1", e.Describe(better), e.Describe(worse), e.Compare(better, worse))
+				}
+			}
+		}
+	}
+}
+
+// partialKey is the hand rank made by hand's rank counts, followed by the
+// strengths of its ranks, most common first and then highest first.
+func partialKey(hand []Card, low bool) string {
+	var counts [14]int
+	for _, card := range hand {
+		if card.Rank == Ace && !low {
+			counts[13]++
+		} else {
+			counts[card.Rank]++
+		}
+	}
+
+	var key []byte
+	for n := 4; n > 0; n-- {
+		for cs := 13; cs >= 0; cs-- {
+			if counts[cs] == n {
+				key = append(key, byte(cs))
+			}
+		}
+	}
+
+	var rank HandRank
+	switch max, pairs := counts[key[0]], len(hand)-len(key); {
+	case max == 4:
+		rank = Quads
+	case max == 3:
+		rank = Trips
+	case pairs == 2:
+		rank = TwoPair
+	case pairs == 1:
+		rank = Pair
+	default:
+		rank = HighCard
+	}
+	return string(append([]byte{byte(rank)}, key...))
+}
+
+// comparePartialKeys returns 1 if a is the better hand, -1 if b is, else 0.
+// Low hands are better with a lower hand rank and lower ranks.
+func comparePartialKeys(a, b string, low bool) int {
+	c := strings.Compare(a, b)
+	if low {
+		return -c
+	}
+	return c
+}
+
+func TestPartialCompare(t *testing.T) {
+	for _, test := range []struct {
+		e    Evaluator
+		a, b string
+		c    int
+	}{
+		{PartialHigh, "9s9d", "AsKs", 1},
+		{PartialHigh, "As", "Ks", 1},
+		{PartialHigh, "AsKd", "AcQd", 1},
+		{PartialHigh, "3s3d2c", "AsKsQs", 1},
+		{PartialHigh, "3s3d2c", "3c3h4c", -1},
+		{PartialHigh, "2s2d2c", "AsAdKsKd", 1},
+		{PartialHigh, "AsAdKsKd", "AcAhKcQd", 1},
+		{PartialHigh, "5c6c7c8c", "9d2h3h4h", -1},
+		{PartialHigh, "KsQsJsTs", "KdQdJdTd", 0},
+		{PartialLow, "AsKs", "9s9d", 1},
+		{PartialLow, "As2s", "As3s", 1},
+		{PartialLow, "8s7d2c", "8c6d5h", -1},
+		{PartialLow, "As2s2d", "KsQsJs", -1},
+		{PartialLow, "KsKdQsQd", "2s2d2c", 1},
+		{PartialLow, "AsAd", "2s2d", 1},
+		{PartialLow, "5c4c3c2c", "5d4d3d2d", 0},
+	} {
+		a, b := test.e.Evaluate(readCards(t, test.a)), test.e.Evaluate(readCards(t, test.b))
+		if c := test.e.Compare(a, b); c != test.c {
+			t.Errorf("%s (%s) compared to %s (%s) is %d, expected %d", test.a, test.e.Describe(a), test.b, test.e.Describe(b), c, test.c)
+		}
+	}
+
+	assertDescription(t, PartialHigh, "9s9dAh", "Pair of Nines with an Ace kicker")
+	assertDescription(t, PartialLow, "7s4d2h", "Seven-Four low")
+	assertExplanation(t, PartialHigh, "9s9d", "AsKs", "wins with Pair of Nines over Ace High with a King kicker")
+}
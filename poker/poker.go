@@ -2,15 +2,8 @@ package poker
 
 import (
 	. "github.com/dohodges/fifty2"
-	"github.com/golang/groupcache/lru"
 )
 
-var cache *lru.Cache
-
-func init() {
-	cache = lru.New(2598960)
-}
-
 type HandRank uint8
 
 const (
@@ -24,14 +17,18 @@ const (
 	FullHouse
 	Quads
 	StraightFlush
+	// FiveOfAKind is only made with wild cards.
+	FiveOfAKind
 )
 
 func HandRanks() []HandRank {
-	return []HandRank{StraightFlush, Quads, FullHouse, Flush, Straight, Trips, TwoPair, Pair, HighCard}
+	return []HandRank{FiveOfAKind, StraightFlush, Quads, FullHouse, Flush, Straight, Trips, TwoPair, Pair, HighCard}
 }
 
 func (hr HandRank) String() string {
 	switch hr {
+	case FiveOfAKind:
+		return "Five of a Kind"
 	case StraightFlush:
 		return "Straight Flush"
 	case Quads:
@@ -116,18 +113,45 @@ func MinHandStrength(strengths []HandStrength) HandStrength {
 	return min
 }
 
+// GetHandStrength is safe for concurrent use. Hands of up to 7 cards are
+// evaluated with lookup tables; larger hands are calculated and cached. Hands
+// with a joker are NoHand, as jokers are only scored by a WildEvaluator.
 func GetHandStrength(hand []Card) HandStrength {
-	if strength, hit := cache.Get(Mask(hand)); hit {
-		return strength.(HandStrength)
+	if strength, ok := lookupHandStrength(hand); ok {
+		return strength
+	}
+
+	hc := getCache()
+	if hc == nil {
+		return calculateHandStrength(hand)
+	}
+
+	mask := Mask(hand)
+	if strength, hit := hc.get(mask); hit {
+		return strength
 	}
 
 	strength := calculateHandStrength(hand)
-	cache.Add(Mask(hand), strength)
+	hc.add(mask, strength)
 
 	return strength
 }
 
+// hasJoker reports whether hand holds a joker, which the natural evaluators
+// can't score.
+func hasJoker(hand []Card) bool {
+	for _, card := range hand {
+		if card.Rank == Joker {
+			return true
+		}
+	}
+	return false
+}
+
 func calculateHandStrength(hand []Card) HandStrength {
+	if hasJoker(hand) {
+		return MakeHandStrength(NoHand, 0, 0, 0)
+	}
 
 	var (
 		bitSet     uint16
@@ -216,7 +240,12 @@ func calculateHandStrength(hand []Card) HandStrength {
 	return MakeHandStrength(HighCard, 0, 0, getKickers(bitSet, 5))
 }
 
+// GetLowHandStrength scores an ace-to-five low. Hands with a joker are
+// NoHand, as for GetHandStrength.
 func GetLowHandStrength(hand []Card, eightOrBetter bool) HandStrength {
+	if hasJoker(hand) {
+		return MakeHandStrength(NoHand, 0, 0, 0)
+	}
 
 	var (
 		bitSet    uint16
@@ -231,7 +260,7 @@ func GetLowHandStrength(hand []Card, eightOrBetter bool) HandStrength {
 	// high card
 	kickers, found := getLowKickers(bitSet, 5)
 	if found == 5 || found == len(hand) {
-		if !eightOrBetter || kickers < CardStrength(9).Mask() {
+		if !eightOrBetter || kickers < CardStrength(Nine).Mask() {
 			return MakeHandStrength(HighCard, 0, 0, kickers)
 		}
 	}
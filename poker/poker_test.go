@@ -1,7 +1,9 @@
 package poker
 
 import (
+	"fmt"
 	. "github.com/dohodges/fifty2"
+	"sync"
 	"testing"
 )
 
@@ -175,3 +177,46 @@ func assertStrength(t *testing.T, actual, expect HandStrength) {
 		t.Errorf("expected - %#X\nactual - %#X", expect, actual)
 	}
 }
+
+func TestConcurrentHandStrength(t *testing.T) {
+	defer SetCacheSize(DefaultCacheSize)
+
+	for _, size := range []int{1000, 0} {
+		SetCacheSize(size)
+		var wg sync.WaitGroup
+		errs := make(chan string, 8)
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(seed int64) {
+				defer wg.Done()
+				deck := NewDeck()
+				src := NewSource(seed)
+				for i := 0; i < 2000; i++ {
+					hand := SampleWith(deck, 5+i%6, src)
+					if GetHandStrength(hand) != calculateHandStrength(hand) {
+						errs <- fmt.Sprintf("incorrect strength for %v", hand)
+						return
+					}
+				}
+			}(int64(g))
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCacheSize(t *testing.T) {
+	defer SetCacheSize(DefaultCacheSize)
+
+	SetCacheMemory(1 << 20)
+	if size := CacheSize(); size != (1<<20)/cacheEntryBytes {
+		t.Errorf("cache size %d", size)
+	}
+	SetCacheSize(-1)
+	if size := CacheSize(); size != 0 || getCache() != nil {
+		t.Errorf("cache not disabled")
+	}
+}
@@ -9,23 +9,39 @@ import (
 	"os"
 	"runtime/pprof"
 	"strings"
+	"time"
 )
 
 var (
-	game      Game
-	board     []Card
-	hands     [][]Card
-	choose    []int
-	fullBoard []Card
-	fullHands [][]Card
+	game   Game
+	approx bool
+	seed   int64
 )
 
+// spot is the known cards of one calculation, with the full boards and hands
+// that each outcome completes.
+type spot struct {
+	boards     [][]Card
+	hands      [][]Card
+	deck       []Card
+	deckChoose int
+	choose     []int
+	fullBoards [][]Card
+	fullHands  [][]Card
+}
+
 type GameTally []*Tally
 
 func NewGameTally(players int) GameTally {
 	gt := make(GameTally, players)
 	for i := 0; i < players; i++ {
 		gt[i] = &Tally{}
+		if game.BoardCount() > 1 {
+			gt[i].Boards = make([]*Tally, game.BoardCount())
+			for b := range gt[i].Boards {
+				gt[i].Boards[b] = &Tally{}
+			}
+		}
 	}
 	return gt
 }
@@ -53,39 +69,59 @@ func (gt GameTally) Delta(gt2 GameTally) float64 {
 	return absDelta / deltas
 }
 
+// Tally counts a player's results. In multi-board games Scoops and Splits
+// count winning all or part of the pot, and Boards tallies each board.
 type Tally struct {
 	Scoops int64
+	Splits int64
 	HiWins int64
 	LoWins int64
 	HiTies int64
 	LoTies int64
+	Shares float64
 	Total  int64
+	Boards []*Tally
 }
 
 func (t *Tally) Clone() *Tally {
-	return &Tally{
+	clone := &Tally{
 		Scoops: t.Scoops,
+		Splits: t.Splits,
 		HiWins: t.HiWins,
 		LoWins: t.LoWins,
 		HiTies: t.HiTies,
 		LoTies: t.LoTies,
+		Shares: t.Shares,
 		Total:  t.Total,
 	}
+	for _, board := range t.Boards {
+		clone.Boards = append(clone.Boards, board.Clone())
+	}
+	return clone
 }
 
 func (t *Tally) Add(t2 *Tally) {
 	t.Scoops += t2.Scoops
+	t.Splits += t2.Splits
 	t.HiWins += t2.HiWins
 	t.LoWins += t2.LoWins
 	t.HiTies += t2.HiTies
 	t.LoTies += t2.LoTies
+	t.Shares += t2.Shares
 	t.Total += t2.Total
+	for i, board := range t.Boards {
+		board.Add(t2.Boards[i])
+	}
 }
 
 func (t *Tally) ScoopOdds() float64 {
 	return 100. * float64(t.Scoops) / float64(t.Total)
 }
 
+func (t *Tally) SplitOdds() float64 {
+	return 100. * float64(t.Splits) / float64(t.Total)
+}
+
 func (t *Tally) HiWinOdds() float64 {
 	return 100. * float64(t.HiWins) / float64(t.Total)
 }
@@ -102,7 +138,15 @@ func (t *Tally) LoTieOdds() float64 {
 	return 100. * float64(t.LoTies) / float64(t.Total)
 }
 
+// EquityOdds is the share of the pot won, splitting ties evenly.
+func (t *Tally) EquityOdds() float64 {
+	return 100. * t.Shares / float64(t.Total)
+}
+
 func (t *Tally) Delta(t2 *Tally) float64 {
+	if len(t.Boards) > 0 {
+		return math.Abs(t.EquityOdds() - t2.EquityOdds())
+	}
 	return (math.Abs(t.HiWinOdds() - t2.HiWinOdds()) + math.Abs(t.LoWinOdds() - t2.LoWinOdds())) / 2.
 }
 
@@ -111,13 +155,16 @@ func main() {
 	var (
 		gameFlag  string
 		boardFlag string
-		approx    bool
+		deadFlag  string
 		profile   string
+		boards    [][]Card
 	)
 
-	flag.StringVar(&gameFlag, "game", string(Holdem), "game")
-	flag.StringVar(&boardFlag, "board", "", "community cards")
+	flag.StringVar(&gameFlag, "game", string(Holdem), fmt.Sprintf("game, one of %v", Games()))
+	flag.StringVar(&boardFlag, "board", "", "community cards, with boards separated by / in multi-board games")
+	flag.StringVar(&deadFlag, "dead", "", "dead cards, such as discards")
 	flag.BoolVar(&approx, "approx", false, "approximate")
+	flag.Int64Var(&seed, "seed", time.Now().UnixNano(), "random seed for approximation")
 	flag.StringVar(&profile, "profile", "", "create cpu profile")
 	flag.Parse()
 
@@ -131,31 +178,44 @@ func main() {
 		defer pprof.StopCPUProfile()
 	}
 
-	game = GetGame(GameType(gameFlag))
-	if game.Name == "" {
-		fmt.Printf("potodds: unknown game - %s\n", gameFlag)
+	var err error
+	game, err = LookupGame(GameType(gameFlag))
+	if err != nil {
+		fmt.Printf("potodds: unknown game - %s, expected one of %v\n", gameFlag, Games())
 		os.Exit(1)
 	}
 
-	var err error
-	board, err = NewCardReader(strings.NewReader(boardFlag)).ReadAll()
+	boards = make([][]Card, 0, game.BoardCount())
+	for _, boardArg := range strings.Split(boardFlag, "/") {
+		cards, err := NewCardReader(strings.NewReader(boardArg)).ReadAll()
+		if err != nil {
+			fmt.Printf("potodds: invalid board - %v\n", err)
+			os.Exit(1)
+		}
+		boards = append(boards, cards)
+	}
+	// an empty board is every board
+	for boardFlag == "" && len(boards) < game.BoardCount() {
+		boards = append(boards, []Card{})
+	}
+	board, err := game.JoinBoards(boards)
 	if err != nil {
 		fmt.Printf("potodds: invalid board - %v\n", err)
 		os.Exit(1)
-	} else if len(board) > game.BoardSize {
-		fmt.Printf("potodds: %s has a maximum of %d community cards\n", game.Name, game.BoardSize)
+	}
+
+	dead, err := NewCardReader(strings.NewReader(deadFlag)).ReadAll()
+	if err != nil {
+		fmt.Printf("potodds: invalid dead cards - %v\n", err)
 		os.Exit(1)
 	}
 
-	hands = make([][]Card, flag.NArg())
+	hands := make([][]Card, flag.NArg())
 	for i, arg := range flag.Args() {
 		hand, err := NewCardReader(strings.NewReader(arg)).ReadAll()
 		if err != nil {
 			fmt.Printf("potodds: invalid hand - %v\n", err)
 			os.Exit(1)
-		} else if len(hand) > game.HandSize {
-			fmt.Printf("potodds: %s has a maximum hand size of %d\n", game.Name, game.HandSize)
-			os.Exit(1)
 		}
 		hands[i] = hand
 	}
@@ -165,157 +225,297 @@ func main() {
 		os.Exit(1)
 	}
 
-	deck := NewDeck()
-	deck = Remove(deck, board...)
+	if err := game.Validate(board, hands...); err != nil {
+		fmt.Printf("potodds: %v\n", err)
+		os.Exit(1)
+	}
+	known := Mask(board)
 	for _, hand := range hands {
-		deck = Remove(deck, hand...)
+		known |= Mask(hand)
+	}
+	for _, card := range dead {
+		if known&card.Mask() != 0 {
+			fmt.Printf("potodds: dead card %s is dealt more than once\n", card)
+			os.Exit(1)
+		}
+		known |= card.Mask()
 	}
 
-	// copy known cards to full board and hands
-	fullBoard = make([]Card, game.BoardSize)
-	copy(fullBoard, board)
-	fullHands = make([][]Card, len(hands))
+	s := newSpot(board, hands, dead)
+	gameTally, outcomes := s.calculate()
+	if approx {
+		fmt.Printf("Seed - %d\n", seed)
+		fmt.Printf("Iterations - %d\n", outcomes)
+	} else {
+		fmt.Printf("Combinations - %d\n", outcomes)
+	}
+	// results
+	fmt.Printf("Game - %s\n", game.Name)
+	if game.BoardSize > 0 && len(boards) == 1 {
+		fmt.Printf("Board %s\n", board)
+	} else if game.BoardSize > 0 {
+		for b, cards := range boards {
+			fmt.Printf("Board %d %s\n", b+1, cards)
+		}
+	}
+	for i, tally := range gameTally {
+		if len(tally.Boards) == 0 {
+			describeTally(fmt.Sprintf("Player %2d", i+1), tally, hands[i])
+			continue
+		}
+		// multi-board games, scoops win every board
+		fmt.Printf("Player %2d - equity: %6.2f%%  scoop: %6.2f%%  split: %6.2f%%  %s\n", i+1,
+			tally.EquityOdds(), tally.ScoopOdds(), tally.SplitOdds(), hands[i])
+		for b, boardTally := range tally.Boards {
+			describeTally(fmt.Sprintf("  Board %d", b+1), boardTally, hands[i])
+		}
+	}
+
+	// every card is known, describe the showdown
+	if s.deckChoose == 0 {
+		for b, fullBoard := range s.fullBoards {
+			label := ""
+			if len(s.fullBoards) > 1 {
+				label = fmt.Sprintf("Board %d ", b+1)
+			}
+			if game.HasHiHand() {
+				describeShowdown(label+"Hi", game.Hi, game.HiHand, fullBoard, s.fullHands)
+			}
+			if game.HasLoHand() {
+				describeShowdown(label+"Lo", game.Lo, game.LoHand, fullBoard, s.fullHands)
+			}
+		}
+	}
+
+	// hands that have yet to discard keep their best cards on the board they
+	// discard on above, try each discard against the other hands
+	if game.Discards > 0 && len(board) >= game.DiscardAt {
+		for i, hand := range hands {
+			if len(hand) == game.HandSize {
+				describeDiscards(board, hands, dead, i)
+			}
+		}
+	}
+
+}
+
+func describeTally(label string, tally *Tally, hand []Card) {
+	if game.IsHiLo() {
+		fmt.Printf("%s - Scoop: %6.2f%%  HiWin: %6.2f%%  LoWin: %6.2f%% HiTie: %6.2f%%  LoTie: %6.2f%%  %s\n",
+			label, tally.ScoopOdds(), tally.HiWinOdds(), tally.LoWinOdds(), tally.HiTieOdds(), tally.LoTieOdds(), hand)
+	} else if game.HasHiHand() {
+		fmt.Printf("%s - win: %6.2f%%  tie: %6.2f%%  %s\n", label,
+			tally.HiWinOdds(), tally.HiTieOdds(), hand)
+	} else if game.HasLoHand() {
+		fmt.Printf("%s - win: %6.2f%%  tie: %6.2f%%  %s\n", label,
+			tally.LoWinOdds(), tally.LoTieOdds(), hand)
+	}
+}
+
+// newSpot sets up the calculation of hands on board, with the dead cards out
+// of the deck.
+func newSpot(board []Card, hands [][]Card, dead []Card) *spot {
+	s := &spot{
+		boards: game.SplitBoard(board),
+		hands:  hands,
+	}
+
+	s.deck = game.NewDeck()
+	s.deck = Remove(s.deck, board...)
+	s.deck = Remove(s.deck, dead...)
+	for _, hand := range hands {
+		s.deck = Remove(s.deck, hand...)
+	}
+
+	// copy known cards to full boards and hands
+	s.fullBoards = make([][]Card, len(s.boards))
+	for b, cards := range s.boards {
+		s.fullBoards[b] = make([]Card, game.BoardSize)
+		copy(s.fullBoards[b], cards)
+	}
+	s.fullHands = make([][]Card, len(hands))
 	for i, hand := range hands {
-		fullHands[i] = make([]Card, game.HandSize)
-		copy(fullHands[i], hand)
+		s.fullHands[i] = make([]Card, dealtSize(board, hand))
+		copy(s.fullHands[i], hand)
 	}
 
-	// determine # cards to deal to board and each hand
-	deckChoose := game.BoardSize - len(board)
-	choose = make([]int, len(hands)+1)
-	choose[0] = game.BoardSize - len(board)
+	// determine # cards to deal to each board and hand
+	s.choose = make([]int, 0, len(s.boards)+len(hands))
+	for _, cards := range s.boards {
+		s.deckChoose += game.BoardSize - len(cards)
+		s.choose = append(s.choose, game.BoardSize-len(cards))
+	}
 	for i, hand := range hands {
-		deckChoose += game.HandSize - len(hand)
-		choose[i+1] = game.HandSize - len(hand)
+		s.deckChoose += len(s.fullHands[i]) - len(hand)
+		s.choose = append(s.choose, len(s.fullHands[i])-len(hand))
 	}
+	return s
+}
 
-	gameTally := NewGameTally(len(hands))
+// calculate tallies the outcomes of the spot. It returns the tally and the
+// number of outcomes tallied.
+func (s *spot) calculate() (GameTally, int64) {
+	deck, deckChoose := s.deck, s.deckChoose
+	gameTally := NewGameTally(len(s.hands))
 
 	if approx {
+		// each deal is sampled from its own stream so it can be replayed alone
+		source := NewSource(seed)
 		iterations := 0
 		for {
 			lastTally := gameTally.Clone()
 			for i := 0; i < 100; i++ {
-				Shuffle(deck)
-				deal := deck[:deckChoose]
-				gameTally.Add(TallyDeal(deal))
+				deal := SampleWith(deck, deckChoose, source.Stream(uint64(iterations)))
+				gameTally.Add(s.TallyDeal(deal))
 				iterations++
 			}
 			if iterations > 100 && gameTally.Delta(lastTally) < .001 {
-				fmt.Printf("Iterations - %d\n", iterations)
-				break
+				return gameTally, int64(iterations)
 			}
 		}
-	} else {
-		// tally each possible outcome
-		fmt.Printf("Combinations - %d\n", combination(len(deck), deckChoose))
-		for itr := Combinations(deck, deckChoose); itr.HasNext(); {
-			gameTally.Add(TallyDeal(itr.Next()))
-		}
 	}
 
-	// results
-	fmt.Printf("Game - %s\n", game.Name)
-	if game.BoardSize > 0 {
-		fmt.Printf("Board %s\n", board)
-	}
-	for i, tally := range gameTally {
-		if game.IsHiLo() {
-			fmt.Printf("Player %2d - Scoop: %6.2f%%  HiWin: %6.2f%%  LoWin: %6.2f%% HiTie: %6.2f%%  LoTie: %6.2f%%  %s\n",
-			i+1, tally.ScoopOdds(), tally.HiWinOdds(), tally.LoWinOdds(), tally.HiTieOdds(), tally.LoTieOdds(), hands[i])
-		} else if game.HasHiHand() {
-			fmt.Printf("Player %2d - win: %6.2f%%  tie: %6.2f%%  %s\n", i+1,
-				tally.HiWinOdds(), tally.HiTieOdds(), hands[i])
-		} else if game.HasLoHand() {
-			fmt.Printf("Player %2d - win: %6.2f%%  tie: %6.2f%%  %s\n", i+1,
-				tally.LoWinOdds(), tally.LoTieOdds(), hands[i])
-		}
+	// tally each possible outcome
+	for itr := Combinations(deck, deckChoose); itr.HasNext(); {
+		gameTally.Add(s.TallyDeal(itr.Next()))
 	}
+	return gameTally, combination(len(deck), deckChoose)
+}
 
+// dealtSize returns the number of cards hand is dealt. Hands given with only
+// their kept cards once it's time to discard have already discarded.
+func dealtSize(board, hand []Card) int {
+	if game.Discards > 0 && len(board) >= game.DiscardAt && len(hand) <= game.KeptCards() {
+		return game.KeptCards()
+	}
+	return game.HandSize
 }
 
-func TallyDeal(deal []Card) GameTally {
-	tally := NewGameTally(len(hands))
+// describeDiscards tries each discard from the hand of player against the
+// other hands, with the discarded cards dead.
+func describeDiscards(board []Card, hands [][]Card, dead []Card, player int) {
+	fmt.Printf("Player %2d discards\n", player+1)
+
+	var best []Card
+	bestEquity := -1.
+	for itr := Combinations(hands[player], game.KeptCards()); itr.HasNext(); {
+		keep := itr.Next()
+		discard := Remove(append([]Card{}, hands[player]...), keep...)
+
+		keptHands := make([][]Card, len(hands))
+		copy(keptHands, hands)
+		keptHands[player] = keep
+
+		deadCards := append(append([]Card{}, dead...), discard...)
+		gameTally, _ := newSpot(board, keptHands, deadCards).calculate()
+
+		tally := gameTally[player]
+		fmt.Printf("  discard %s - equity: %6.2f%%  win: %6.2f%%  tie: %6.2f%%  %s\n", discard,
+			tally.EquityOdds(), tally.HiWinOdds()+tally.LoWinOdds()+tally.ScoopOdds(), tally.HiTieOdds()+tally.LoTieOdds(), keep)
+		if tally.EquityOdds() > bestEquity {
+			best, bestEquity = discard, tally.EquityOdds()
+		}
+	}
+	fmt.Printf("Player %2d best discard - %s\n", player+1, best)
+}
 
-	var hiStrengths, loStrengths []HandStrength
-	if game.HasHiHand() {
-		hiStrengths = make([]HandStrength, len(fullHands))
+func describeShowdown(label string, e Evaluator, bestHand func(board, pocket []Card) MadeHand, fullBoard []Card, fullHands [][]Card) {
+	made := make([]MadeHand, len(fullHands))
+	strengths := make([]HandStrength, len(fullHands))
+	for i, fullHand := range fullHands {
+		made[i] = bestHand(fullBoard, fullHand)
+		strengths[i] = made[i].Strength
 	}
-	if game.HasLoHand() {
-		loStrengths = make([]HandStrength, len(fullHands))
+
+	best := Best(e, strengths)
+	for i := range made {
+		if !made[i].IsQualified() {
+			fmt.Printf("%s %2d - %s\n", label, i+1, e.Describe(strengths[i]))
+			continue
+		}
+		var result string
+		if len(best) > 0 && best[0] == i && len(best) > 1 {
+			result = "ties"
+		} else if len(best) > 0 && best[0] == i {
+			runnerUp := -1
+			for j := range strengths {
+				if j != i && (runnerUp < 0 || e.Compare(strengths[j], strengths[runnerUp]) > 0) {
+					runnerUp = j
+				}
+			}
+			result = Explain(e, strengths[i], strengths[runnerUp])
+		} else if len(best) > 0 {
+			result = Explain(e, strengths[i], strengths[best[0]])
+		}
+		fmt.Printf("%s %2d - %s %s - %s\n", label, i+1, e.Describe(strengths[i]), made[i].Cards, result)
 	}
+}
+
+// TallyDeal tallies each way deal completes the boards and hands. It counts
+// like the equity package's showdown, but keeps a tally for each board and
+// counts a board's hi and lo won by one hand as a scoop, which
+// equity.Result averages away. Hands are known cards, not ranges.
+func (s *spot) TallyDeal(deal []Card) GameTally {
+	tally := NewGameTally(len(s.hands))
 
 	// each possible deal
-	for itr := MultipleCombinations(deal, choose); itr.HasNext(); {
+	for itr := MultipleCombinations(deal, s.choose); itr.HasNext(); {
 		dealCombo := itr.Next()
-		copy(fullBoard[len(board):], dealCombo[0])
-		for i, fullHand := range fullHands {
-			copy(fullHand[len(hands[i]):], dealCombo[i+1])
-			if game.HasHiHand() {
-				hiStrengths[i] = game.HiStrength(fullBoard, fullHand)
-			}
-			if game.HasLoHand() {
-				loStrengths[i] = game.LoStrength(fullBoard, fullHand)
-			}
+		for b, fullBoard := range s.fullBoards {
+			copy(fullBoard[len(s.boards[b]):], dealCombo[b])
+		}
+		for i, fullHand := range s.fullHands {
+			copy(fullHand[len(s.hands[i]):], dealCombo[len(s.boards)+i])
 		}
 
-		// tally wins & ties
-		bestHi := GetBestHiHands(hiStrengths)
-		bestLo := GetBestLoHands(loStrengths)
-
-		if len(bestHi) == 1 && len(bestLo) == 1 && bestHi[0] == bestLo[0] {
-			tally[bestHi[0]].Scoops++
-		} else {
-			if len(bestHi) == 1 {
-				tally[bestHi[0]].HiWins++
-			} else if len(bestHi) > 1 {
-				for _, h := range bestHi {
-					tally[h].HiTies++
-				}
+		bestHi, bestLo := game.Showdown(s.fullBoards, s.fullHands...)
+		for b := range s.fullBoards {
+			if len(s.fullBoards) == 1 {
+				tally.addWinners(bestHi[b], bestLo[b])
+				continue
 			}
-			if len(bestLo) == 1 {
-				tally[bestLo[0]].LoWins++
-			} else if len(bestLo) > 1 {
-				for _, h := range bestLo {
-					tally[h].LoTies++
-				}
+			boardTally := make(GameTally, len(tally))
+			for i, t := range tally {
+				boardTally[i] = t.Boards[b]
+				boardTally[i].Total++
 			}
+			boardTally.addWinners(bestHi[b], bestLo[b])
 		}
 
-		for _, t := range tally {
-			t.Total++
+		for i, share := range PotShares(bestHi, bestLo, len(s.hands)) {
+			tally[i].Shares += share
+			tally[i].Total++
+			if len(s.fullBoards) > 1 && share == 1 {
+				tally[i].Scoops++
+			} else if len(s.fullBoards) > 1 && share > 0 {
+				tally[i].Splits++
+			}
 		}
 	}
 
 	return tally
 }
 
-func GetBestHiHands(strengths []HandStrength) []int {
-	max := MakeHandStrength(NoHand, 0, 0, 0)
-	best := make([]int, 0, len(strengths))
-	for i, strength := range strengths {
-		if strength > max {
-			max = strength
-			best = append(best[0:0], i)
-		} else if strength == max {
-			best = append(best, i)
+// addWinners tallies the wins & ties of one board.
+func (gt GameTally) addWinners(bestHi, bestLo []int) {
+	if len(bestHi) == 1 && len(bestLo) == 1 && bestHi[0] == bestLo[0] {
+		gt[bestHi[0]].Scoops++
+	} else {
+		if len(bestHi) == 1 {
+			gt[bestHi[0]].HiWins++
+		} else if len(bestHi) > 1 {
+			for _, h := range bestHi {
+				gt[h].HiTies++
+			}
 		}
-	}
-	return best
-}
-
-func GetBestLoHands(strengths []HandStrength) []int {
-	min := MakeHandStrength(StraightFlush, 0, 0, 0)
-	best := make([]int, 0, len(strengths))
-	for i, strength := range strengths {
-		if strength.Rank() != NoHand && strength < min {
-			min = strength
-			best = append(best[0:0], i)
-		} else if strength == min {
-			best = append(best, i)
+		if len(bestLo) == 1 {
+			gt[bestLo[0]].LoWins++
+		} else if len(bestLo) > 1 {
+			for _, h := range bestLo {
+				gt[h].LoTies++
+			}
 		}
 	}
-	return best
 }
 
 func combination(n, k int) int64 {
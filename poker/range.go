@@ -0,0 +1,42 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+)
+
+// Combo is one holding in a range. Weight is how often the holding is played
+// relative to the other combos, such as 0.5 for a hand played half the time.
+type Combo struct {
+	Cards  []Card
+	Weight float64
+}
+
+// Range is the holdings a player could have.
+type Range []Combo
+
+// HandRange returns the range of a single known hand.
+func HandRange(hand []Card) Range {
+	return Range{Combo{hand, 1}}
+}
+
+// Without returns the combos that hold none of cards, such as the board and
+// dead cards, and drops combos without weight.
+func (r Range) Without(cards ...Card) Range {
+	mask := Mask(cards)
+	without := make(Range, 0, len(r))
+	for _, combo := range r {
+		if combo.Weight > 0 && Mask(combo.Cards)&mask == 0 {
+			without = append(without, combo)
+		}
+	}
+	return without
+}
+
+// Weight returns the total weight of the combos.
+func (r Range) Weight() float64 {
+	weight := 0.
+	for _, combo := range r {
+		weight += combo.Weight
+	}
+	return weight
+}
@@ -0,0 +1,92 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+)
+
+var (
+	ShortDeckHigh      Evaluator = ShortDeckEvaluator{TripsBeatStraight: false}
+	ShortDeckTripsHigh Evaluator = ShortDeckEvaluator{TripsBeatStraight: true}
+)
+
+// ShortDeckEvaluator ranks hands dealt from the 36 card short deck. A flush
+// beats a full house and A-6-7-8-9 is the lowest straight. Under some rules,
+// such as Triton's, three of a kind also beats a straight.
+//
+// Strengths are made with the usual hand ranks, so only Compare orders them
+// correctly.
+type ShortDeckEvaluator struct {
+	TripsBeatStraight bool
+}
+
+// short deck wheel - 0000 0001 1110 0001
+const shortDeckWheel = uint16(0x01E1)
+
+func (e ShortDeckEvaluator) Evaluate(hand []Card) HandStrength {
+	if len(hand) <= 5 {
+		return getShortDeckStrength(hand)
+	}
+
+	best := MakeHandStrength(NoHand, 0, 0, 0)
+	for itr := Combinations(hand, 5); itr.HasNext(); {
+		if strength := getShortDeckStrength(itr.Next()); e.Compare(strength, best) > 0 {
+			best = strength
+		}
+	}
+	return best
+}
+
+// getShortDeckStrength evaluates a hand of five cards or fewer, where the
+// hand is only ever one of straight, flush or full house.
+func getShortDeckStrength(hand []Card) HandStrength {
+	strength := GetHandStrength(hand)
+	if len(hand) < 5 || strength.Rank() != HighCard && strength.Rank() != Flush {
+		return strength
+	}
+
+	bitSet := uint16(0)
+	for _, card := range hand {
+		bitSet |= card.Rank.Mask()
+	}
+	if bitSet == shortDeckWheel {
+		if strength.Rank() == Flush {
+			return MakeHandStrength(StraightFlush, CardStrength(Nine), 0, 0)
+		}
+		return MakeHandStrength(Straight, CardStrength(Nine), 0, 0)
+	}
+	return strength
+}
+
+func (e ShortDeckEvaluator) Compare(a, b HandStrength) int {
+	if ra, rb := e.order(a.Rank()), e.order(b.Rank()); ra != rb {
+		return compareHigh(HandStrength(ra), HandStrength(rb))
+	}
+	return compareHigh(a, b)
+}
+
+// order returns the position of hr in the short deck hand rankings.
+func (e ShortDeckEvaluator) order(hr HandRank) HandRank {
+	switch {
+	case hr == Flush:
+		return FullHouse
+	case hr == FullHouse:
+		return Flush
+	case e.TripsBeatStraight && hr == Trips:
+		return Straight
+	case e.TripsBeatStraight && hr == Straight:
+		return Trips
+	}
+	return hr
+}
+
+func (ShortDeckEvaluator) Describe(strength HandStrength) string {
+	return DescribeHigh(strength)
+}
+
+func (e ShortDeckEvaluator) BestFive(hand []Card) ([]Card, HandStrength) {
+	return BestFive(e, hand)
+}
+
+func (e ShortDeckEvaluator) Explain(a, b HandStrength) string {
+	return explain(e, a, b, false)
+}
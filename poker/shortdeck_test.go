@@ -0,0 +1,107 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"testing"
+)
+
+func TestShortDeckHands(t *testing.T) {
+	counts := make(map[HandRank]int)
+	for itr := Combinations(NewShortDeck(), 5); itr.HasNext(); {
+		counts[ShortDeckHigh.Evaluate(itr.Next()).Rank()]++
+	}
+
+	expected := map[HandRank]int{
+		StraightFlush: 24,
+		Quads:         288,
+		FullHouse:     1728,
+		Flush:         480,
+		Straight:      6120,
+		Trips:         16128,
+		TwoPair:       36288,
+		Pair:          193536,
+		HighCard:      122400,
+	}
+	for rank, count := range expected {
+		if counts[rank] != count {
+			t.Errorf("%d %s hands, expected %d", counts[rank], rank, count)
+		}
+	}
+}
+
+func TestShortDeckStraights(t *testing.T) {
+	wheel := ShortDeckHigh.Evaluate(readCards(t, "As6d7h8c9s"))
+	if wheel != MakeHandStrength(Straight, CardStrength(Nine), 0, 0) {
+		t.Errorf("A-6-7-8-9 is %s", DescribeHigh(wheel))
+	}
+	if sixHigh := ShortDeckHigh.Evaluate(readCards(t, "6d7h8c9sTs")); ShortDeckHigh.Compare(sixHigh, wheel) <= 0 {
+		t.Errorf("A-6-7-8-9 does not lose to %s", DescribeHigh(sixHigh))
+	}
+	if strength := ShortDeckHigh.Evaluate(readCards(t, "Ah6h7h8h9h")); strength != MakeHandStrength(StraightFlush, CardStrength(Nine), 0, 0) {
+		t.Errorf("A-6-7-8-9 suited is %s", DescribeHigh(strength))
+	}
+	if strength := High.Evaluate(readCards(t, "As6d7h8c9s")); strength.Rank() != HighCard {
+		t.Errorf("A-6-7-8-9 is %s in a full deck", DescribeHigh(strength))
+	}
+}
+
+func TestShortDeckOrder(t *testing.T) {
+	// flush and full house in the same seven cards
+	hand := readCards(t, "AhKhQhJh9hAcAdKc")
+	if strength := ShortDeckHigh.Evaluate(hand); strength.Rank() != Flush {
+		t.Errorf("%v is %s, expected a flush", hand, DescribeHigh(strength))
+	}
+	if strength := High.Evaluate(hand); strength.Rank() != FullHouse {
+		t.Errorf("%v is %s in a full deck", hand, DescribeHigh(strength))
+	}
+
+	flush := ShortDeckHigh.Evaluate(readCards(t, "6h7h8hJh9h"))
+	fullHouse := ShortDeckHigh.Evaluate(readCards(t, "AcAdAhKcKd"))
+	for _, e := range []Evaluator{ShortDeckHigh, ShortDeckTripsHigh} {
+		if e.Compare(flush, fullHouse) <= 0 || e.Compare(fullHouse, flush) >= 0 {
+			t.Errorf("%s does not beat %s", DescribeHigh(flush), DescribeHigh(fullHouse))
+		}
+	}
+
+	// trips and straight in the same seven cards
+	hand = readCards(t, "6c6d6h7s8c9dTc")
+	if strength := ShortDeckHigh.Evaluate(hand); strength.Rank() != Straight {
+		t.Errorf("%v is %s, expected a straight", hand, DescribeHigh(strength))
+	}
+	if strength := ShortDeckTripsHigh.Evaluate(hand); strength.Rank() != Trips {
+		t.Errorf("%v is %s, expected three of a kind", hand, DescribeHigh(strength))
+	}
+
+	straight := ShortDeckHigh.Evaluate(readCards(t, "AsKdQhJcTs"))
+	trips := ShortDeckHigh.Evaluate(readCards(t, "6c6d6h7s8c"))
+	if ShortDeckHigh.Compare(straight, trips) <= 0 {
+		t.Errorf("%s does not beat %s", DescribeHigh(straight), DescribeHigh(trips))
+	}
+	if ShortDeckTripsHigh.Compare(trips, straight) <= 0 {
+		t.Errorf("%s does not beat %s", DescribeHigh(trips), DescribeHigh(straight))
+	}
+	if explanation := Explain(ShortDeckTripsHigh, trips, straight); explanation != "wins with Three of a Kind, Sixes with Eight-Seven over Straight, Ace high" {
+		t.Errorf("explanation - %s", explanation)
+	}
+}
+
+func TestShortDeckGame(t *testing.T) {
+	game := GetGame(ShortDeck)
+	if deck := game.NewDeck(); len(deck) != 36 || Index(deck, Card{Five, Spades}) >= 0 || Index(deck, Card{Ace, Spades}) < 0 {
+		t.Errorf("short deck %v", deck)
+	}
+	if deck := GetGame(Holdem).NewDeck(); len(deck) != 52 {
+		t.Errorf("holdem deck has %d cards", len(deck))
+	}
+
+	board := readCards(t, "6h7h8hKcKd")
+	if strength := game.HiStrength(board, readCards(t, "AhJh")); strength.Rank() != Flush {
+		t.Errorf("%s, expected a flush", DescribeHigh(strength))
+	}
+	if best := Best(game.Hi, []HandStrength{
+		game.HiStrength(board, readCards(t, "AhJh")),
+		game.HiStrength(board, readCards(t, "Ks6c")),
+	}); len(best) != 1 || best[0] != 0 {
+		t.Errorf("best short deck hands %v", best)
+	}
+}
@@ -0,0 +1,116 @@
+package poker
+
+import (
+	"fmt"
+	. "github.com/dohodges/fifty2"
+)
+
+// SplitBoard splits the board cards of a deal, in the order they were dealt,
+// into the game's boards.
+func (g Game) SplitBoard(board []Card) [][]Card {
+	boards := make([][]Card, g.BoardCount())
+	for i := range boards {
+		boards[i] = make([]Card, 0, g.BoardSize)
+	}
+	for _, n := range g.roundBoardCards() {
+		for i := range boards {
+			if n > len(board) {
+				n = len(board)
+			}
+			boards[i] = append(boards[i], board[:n]...)
+			board = board[n:]
+		}
+	}
+	return boards
+}
+
+// JoinBoards returns the board cards of boards in the order they are dealt,
+// the reverse of SplitBoard. The boards must all have the same size.
+func (g Game) JoinBoards(boards [][]Card) ([]Card, error) {
+	if len(boards) != g.BoardCount() {
+		return nil, fmt.Errorf("fifty2/poker: %s has %d boards, not %d", g.Name, g.BoardCount(), len(boards))
+	}
+	for _, cards := range boards {
+		if len(cards) != len(boards[0]) {
+			return nil, fmt.Errorf("fifty2/poker: %s boards %v and %v are different sizes", g.Name, boards[0], cards)
+		}
+	}
+
+	board := make([]Card, 0, len(boards)*len(boards[0]))
+	dealt := 0
+	for _, n := range g.roundBoardCards() {
+		if n > len(boards[0])-dealt {
+			break
+		}
+		for _, cards := range boards {
+			board = append(board, cards[dealt:dealt+n]...)
+		}
+		dealt += n
+	}
+	if dealt != len(boards[0]) {
+		return nil, fmt.Errorf("fifty2/poker: %s board can't have %d cards", g.Name, len(boards[0]))
+	}
+	return board, nil
+}
+
+// roundBoardCards returns the number of cards each round deals to each board.
+func (g Game) roundBoardCards() []int {
+	counts := make([]int, len(g.Deal))
+	for i, round := range g.Deal {
+		for _, step := range round {
+			if step.Action == DealBoard {
+				counts[i] += step.Count
+			}
+		}
+		counts[i] /= g.BoardCount()
+	}
+	return counts
+}
+
+// Showdown returns the hands that win the Hi and Lo halves of each board, as
+// indexes into hands. More than one winner is a tie. A half has no winners
+// if the game doesn't play it or no hand qualifies.
+func (g Game) Showdown(boards [][]Card, hands ...[]Card) (hi, lo [][]int) {
+	hi = make([][]int, len(boards))
+	lo = make([][]int, len(boards))
+	strengths := make([]HandStrength, len(hands))
+	for b, board := range boards {
+		if g.HasHiHand() {
+			for i, hand := range hands {
+				strengths[i] = g.HiStrength(board, hand)
+			}
+			hi[b] = Best(g.Hi, strengths)
+		}
+		if g.HasLoHand() {
+			for i, hand := range hands {
+				strengths[i] = g.LoStrength(board, hand)
+			}
+			lo[b] = Best(g.Lo, strengths)
+		}
+	}
+	return hi, lo
+}
+
+// PotShares returns the share of the pot won by each of the hands, given the
+// Hi and Lo winners of each board from Showdown. The boards split the pot
+// equally and each board's share is split between its Hi and Lo winners.
+// A half without winners goes to the other half.
+func PotShares(hi, lo [][]int, hands int) []float64 {
+	shares := make([]float64, hands)
+	board := 1. / float64(len(hi))
+	for b := range hi {
+		halves := [][]int{hi[b], lo[b]}
+		switch {
+		case len(lo[b]) == 0:
+			halves = halves[:1]
+		case len(hi[b]) == 0:
+			halves = halves[1:]
+		}
+		for _, winners := range halves {
+			for _, i := range winners {
+				shares[i] += board / float64(len(halves)) / float64(len(winners))
+			}
+		}
+	}
+	return shares
+}
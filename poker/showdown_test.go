@@ -0,0 +1,96 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"reflect"
+	"testing"
+)
+
+func TestSplitBoard(t *testing.T) {
+	game := GetGame(HoldemDoubleBoard)
+	dealer := game.NewDealer(2, NewDeck())
+	for _, boards := range [][]string{
+		{"", ""},
+		{"6c7c8c", "TcJcQc"},
+		{"6c7c8cAd", "TcJcQc3d"},
+		{"6c7c8cAd5d", "TcJcQc3d7d"},
+	} {
+		if err := dealer.Deal(); err != nil {
+			t.Fatal(err)
+		}
+		expected := [][]Card{readCards(t, boards[0]), readCards(t, boards[1])}
+		board := dealer.Board()
+		if split := game.SplitBoard(board); !reflect.DeepEqual(split, expected) {
+			t.Errorf("%v split into %v, expected %v", board, split, expected)
+		}
+		if joined, err := game.JoinBoards(expected); err != nil || !reflect.DeepEqual(joined, board) {
+			t.Errorf("%v joined into %v, expected %v - %v", expected, joined, board, err)
+		}
+	}
+
+	for _, boards := range [][][]Card{
+		{readCards(t, "AsKsQs")},
+		{readCards(t, "AsKsQs"), readCards(t, "AhKhQhJh")},
+		{readCards(t, "AsKs"), readCards(t, "AhKh")},
+	} {
+		if joined, err := game.JoinBoards(boards); err == nil {
+			t.Errorf("%v joined into %v", boards, joined)
+		}
+	}
+
+	board := readCards(t, "AsKsQs")
+	if split := GetGame(Holdem).SplitBoard(board); !reflect.DeepEqual(split, [][]Card{board}) {
+		t.Errorf("holdem board split into %v", split)
+	}
+}
+
+func TestShowdown(t *testing.T) {
+	for _, test := range []struct {
+		gt     GameType
+		boards []string
+		hands  []string
+		shares []float64
+	}{
+		{Holdem, []string{"AhKh7c5s2d"}, []string{"AsAd", "QhJh"}, []float64{1, 0}},
+		{Holdem, []string{"AhKhQhJhTh"}, []string{"AsAd", "QcJc", "2c3c"}, []float64{1. / 3, 1. / 3, 1. / 3}},
+		{HoldemDoubleBoard, []string{"AhKh7c5s2d", "2c3c4d9sTs"}, []string{"AsAd", "QhJh"}, []float64{1, 0}},
+		{HoldemDoubleBoard, []string{"AhKh7c5s2h", "KcQc7d8s2s"}, []string{"AsAd", "QhJh"}, []float64{.5, .5}},
+		{HoldemDoubleBoard, []string{"AhKh7c5s2h", "KcKdKs8s8d"}, []string{"AsJd", "QhJh", "AcJc"}, []float64{1. / 6, 2. / 3, 1. / 6}},
+		// no low on the first board, the second board's halves split
+		{OmahaHiLoDoubleBoard, []string{"AhKh7cTsJd", "2c4c8d9sTd"}, []string{"AsAd2h3h", "QhJhTc9c"}, []float64{.25, .75}},
+		{OmahaHiLoDoubleBoard, []string{"AhKh7c5s6d", "2c4c8d9sTs"}, []string{"AsAd2h3h", "QhJhTc9c"}, []float64{.75, .25}},
+		{OmahaHiLoDoubleBoard, []string{"AhKh7c5s6d", "2c3c4d9sKs"}, []string{"AsAd2h3h", "QhJhTc9c"}, []float64{1, 0}},
+	} {
+		game := GetGame(test.gt)
+		boards := make([][]Card, len(test.boards))
+		for i, board := range test.boards {
+			boards[i] = readCards(t, board)
+		}
+		hands := make([][]Card, len(test.hands))
+		for i, hand := range test.hands {
+			hands[i] = readCards(t, hand)
+		}
+		board, err := game.JoinBoards(boards)
+		if err == nil {
+			err = game.Validate(board, hands...)
+		}
+		if err != nil {
+			t.Errorf("%s %v", test.gt, err)
+			continue
+		}
+
+		hi, lo := game.Showdown(boards, hands...)
+		shares := PotShares(hi, lo, len(hands))
+		total := 0.
+		for i := range shares {
+			total += shares[i]
+			if d := shares[i] - test.shares[i]; d > 1e-9 || d < -1e-9 {
+				t.Errorf("%s %v on %v shares %v, expected %v", test.gt, hands, boards, shares, test.shares)
+				break
+			}
+		}
+		if d := total - 1; d > 1e-9 || d < -1e-9 {
+			t.Errorf("%s %v on %v shares %v, total %f", test.gt, hands, boards, shares, total)
+		}
+	}
+}
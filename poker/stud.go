@@ -0,0 +1,77 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+)
+
+// IsStud reports whether the game deals cards face up to each seat.
+func (g Game) IsStud() bool {
+	for _, up := range g.Deal.FaceUp() {
+		if up {
+			return true
+		}
+	}
+	return false
+}
+
+// actionEvaluator returns the evaluator that decides the order of action
+// from the cards showing, high unless the game is only played for low.
+func (g Game) actionEvaluator() Evaluator {
+	if g.HasHiHand() {
+		return PartialHigh
+	}
+	return PartialLow
+}
+
+// BringIn returns the seat that must bring in on the first street, the seat
+// with the worst first upcard for the game. In high games the lowest card
+// brings in and clubs are the lowest suit, so 2♣ always brings in. In low
+// games, such as Razz, the highest card brings in and spades are the highest
+// suit. upcards are each seat's face up cards, as in SeatView, with folded
+// seats left empty. It returns -1 if no seat has an upcard.
+func (g Game) BringIn(upcards [][]Card) int {
+	e := g.actionEvaluator()
+	low := !g.HasHiHand()
+
+	bringIn := -1
+	var worst HandStrength
+	for seat, cards := range upcards {
+		if len(cards) == 0 {
+			continue
+		}
+		strength := e.Evaluate(cards[:1])
+		if bringIn < 0 {
+			bringIn, worst = seat, strength
+			continue
+		}
+		switch c := e.Compare(strength, worst); {
+		case c < 0:
+			bringIn, worst = seat, strength
+		case c == 0 && low && cards[0].Suit > upcards[bringIn][0].Suit:
+			bringIn = seat
+		case c == 0 && !low && cards[0].Suit < upcards[bringIn][0].Suit:
+			bringIn = seat
+		}
+	}
+	return bringIn
+}
+
+// FirstToAct returns the seat that acts first after the first street, the
+// seat showing the best hand for the game: the best high hand, or the best
+// low hand in low games. Ties go to the first seat, counting from the
+// dealer's left. upcards are as for BringIn.
+func (g Game) FirstToAct(upcards [][]Card) int {
+	e := g.actionEvaluator()
+
+	first := -1
+	var best HandStrength
+	for seat, cards := range upcards {
+		if len(cards) == 0 {
+			continue
+		}
+		if strength := e.Evaluate(cards); first < 0 || e.Compare(strength, best) > 0 {
+			first, best = seat, strength
+		}
+	}
+	return first
+}
@@ -0,0 +1,85 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"testing"
+)
+
+func readUpcards(t *testing.T, upcards ...string) [][]Card {
+	seats := make([][]Card, len(upcards))
+	for i, cards := range upcards {
+		seats[i] = readCards(t, cards)
+	}
+	return seats
+}
+
+func TestBringIn(t *testing.T) {
+	for _, test := range []struct {
+		gt      GameType
+		upcards []string
+		seat    int
+	}{
+		{Stud7, []string{"Kc", "2d", "2c", "As"}, 2},
+		{Stud7, []string{"3s", "3h", "Ac", "4c"}, 1},
+		{Stud7, []string{"Ad", "Ac"}, 1},
+		{Stud7HiLo, []string{"Kc", "2d", "", "As"}, 1},
+		{Stud5, []string{"Ts", "9h", "Jc"}, 1},
+		{Razz, []string{"Kc", "Qs", "Ks", "As"}, 2},
+		{Razz, []string{"Ac", "2s", "7d"}, 2},
+		{Razz, []string{"", ""}, -1},
+	} {
+		if seat := GetGame(test.gt).BringIn(readUpcards(t, test.upcards...)); seat != test.seat {
+			t.Errorf("%s %v bring in seat %d, expected %d", test.gt, test.upcards, seat, test.seat)
+		}
+	}
+}
+
+func TestFirstToAct(t *testing.T) {
+	for _, test := range []struct {
+		gt      GameType
+		upcards []string
+		seat    int
+	}{
+		{Stud7, []string{"Kc9d", "2d2h", "AsQs"}, 1},
+		{Stud7, []string{"KcQd", "AsTh", "AhTc"}, 1},
+		{Stud7, []string{"KcQd7h", "", "3s3c2d"}, 2},
+		{Stud7, []string{"5c6c7c8c", "AsAcAdKh"}, 1},
+		{Stud7HiLo, []string{"2c3c4c", "9s9d8h"}, 1},
+		{Razz, []string{"Kc9d", "2d2h", "As5s"}, 2},
+		{Razz, []string{"2d2h", "KcQd"}, 1},
+		{Razz, []string{"As7h", "2c7d", "Ac7c"}, 0},
+		{Stud5, []string{"", ""}, -1},
+	} {
+		if seat := GetGame(test.gt).FirstToAct(readUpcards(t, test.upcards...)); seat != test.seat {
+			t.Errorf("%s %v first to act seat %d, expected %d", test.gt, test.upcards, seat, test.seat)
+		}
+	}
+}
+
+func TestStudDealUpcards(t *testing.T) {
+	for _, gt := range []GameType{Stud7, Stud7HiLo, Stud5, Razz} {
+		if !GetGame(gt).IsStud() {
+			t.Errorf("%s is not stud", gt)
+		}
+	}
+	if GetGame(Holdem).IsStud() {
+		t.Errorf("holdem is stud")
+	}
+
+	game := GetGame(Stud7)
+	dealer := game.NewDealer(4, NewDeck())
+	if err := dealer.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	// third street upcards are the 9th to 12th cards of the deck, 9♣ to Q♣
+	if seat := game.BringIn(dealer.View(0).Upcards); seat != 0 {
+		t.Errorf("%v bring in seat %d, expected 0", dealer.View(0).Upcards, seat)
+	}
+	if err := dealer.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	// K♣ is burned and fourth street is A♦ to 4♦
+	if seat := game.FirstToAct(dealer.View(0).Upcards); seat != 0 {
+		t.Errorf("%v first to act seat %d, expected 0", dealer.View(0).Upcards, seat)
+	}
+}
@@ -0,0 +1,187 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"math/bits"
+	"sync"
+)
+
+// The lookup tables evaluate hands of up to 7 distinct cards. Ranks are fed
+// through a state machine whose states are the multisets of ranks seen so
+// far, each labelled with the strength of a hand of those ranks that isn't
+// a flush. With at most 7 cards a flush can't be beaten by anything but a
+// straight flush in the same suit, so flushes are looked up by the ranks
+// in the flush suit alone. Every entry is computed by calculateHandStrength,
+// so both evaluators agree exactly.
+
+const tableMaxCards = 7
+
+type handTables struct {
+	next  []uint32       // state*13 + rank -> state
+	value []HandStrength // state -> strength without a flush
+	flush []HandStrength // flush suit rank bit set -> strength
+}
+
+var (
+	tablesOnce sync.Once
+	tables     *handTables
+)
+
+func getTables() *handTables {
+	tablesOnce.Do(func() {
+		tables = buildTables()
+	})
+	return tables
+}
+
+const noState = ^uint32(0)
+
+func buildTables() *handTables {
+	t := &handTables{}
+
+	// states are keyed by their rank counts in base 5
+	ids := map[uint32]uint32{0: 0}
+	keys := []uint32{0}
+	sizes := []int{0}
+	pow5 := [13]uint32{}
+	for r, p := 0, uint32(1); r < 13; r, p = r+1, p*5 {
+		pow5[r] = p
+	}
+
+	for id := 0; id < len(keys); id++ {
+		key := keys[id]
+		for r := 0; r < 13; r++ {
+			next := noState
+			if sizes[id] < tableMaxCards && (key/pow5[r])%5 < 4 {
+				nextKey := key + pow5[r]
+				nextID, found := ids[nextKey]
+				if !found {
+					nextID = uint32(len(keys))
+					ids[nextKey] = nextID
+					keys = append(keys, nextKey)
+					sizes = append(sizes, sizes[id]+1)
+				}
+				next = nextID
+			}
+			t.next = append(t.next, next)
+		}
+	}
+
+	t.value = make([]HandStrength, len(keys))
+	hand := make([]Card, 0, tableMaxCards)
+	for id, key := range keys {
+		// deal the ranks round-robin across the suits so no suit has more
+		// than two cards
+		hand = hand[:0]
+		for r := 0; r < 13; r++ {
+			for n := (key / pow5[r]) % 5; n > 0; n-- {
+				hand = append(hand, Card{Rank(r), Suit(len(hand) % 4)})
+			}
+		}
+		t.value[id] = calculateHandStrength(hand)
+	}
+
+	t.flush = make([]HandStrength, 1<<13)
+	for bitSet := range t.flush {
+		if n := bits.OnesCount16(uint16(bitSet)); n < 5 || n > tableMaxCards {
+			continue
+		}
+		hand = hand[:0]
+		for r := 0; r < 13; r++ {
+			if bitSet&(1<<uint(r)) != 0 {
+				hand = append(hand, Card{Rank(r), Spades})
+			}
+		}
+		t.flush[bitSet] = calculateHandStrength(hand)
+	}
+
+	return t
+}
+
+// lookupHandStrength returns false when the hand can't be evaluated by the
+// tables: more than 7 cards, duplicate cards or jokers.
+func lookupHandStrength(hand []Card) (HandStrength, bool) {
+	if len(hand) > tableMaxCards || bits.OnesCount64(Mask(hand)) != len(hand) || hasJoker(hand) {
+		return 0, false
+	}
+
+	t := getTables()
+	var (
+		state      uint32
+		suitBitSet [4]uint16
+		suitCount  [4]uint8
+	)
+	for _, card := range hand {
+		state = t.next[state*13+uint32(card.Rank)]
+		suitBitSet[card.Suit] |= card.Rank.Mask()
+		suitCount[card.Suit]++
+	}
+
+	for suit, count := range suitCount {
+		if count >= 5 {
+			return t.flush[suitBitSet[suit]], true
+		}
+	}
+	return t.value[state], true
+}
+
+// partialHand is a combination of cards fed through the state machine, so
+// other cards can be added without feeding them again.
+type partialHand struct {
+	state  uint32
+	bitSet uint16
+	suit   int // the suit of every card, or negative if mixed or empty
+}
+
+func (t *handTables) partial(cards []Card) partialHand {
+	ph := partialHand{suit: -1}
+	for i, card := range cards {
+		ph.state = t.next[ph.state*13+uint32(card.Rank)]
+		ph.bitSet |= card.Rank.Mask()
+		if i == 0 {
+			ph.suit = int(card.Suit)
+		} else if ph.suit != int(card.Suit) {
+			ph.suit = -2
+		}
+	}
+	return ph
+}
+
+// omahaHighStrength is omahaStrength for the high evaluator. Each board and
+// pocket combination is fed through the state machine once, then every pair
+// of them is joined by feeding the pocket ranks on from the board state. It
+// returns false for duplicate cards or jokers.
+func omahaHighStrength(board, pocket []Card, pocketCards, boardCards int) (HandStrength, bool) {
+	if pocketCards+boardCards > tableMaxCards || bits.OnesCount64(Mask(board)|Mask(pocket)) != len(board)+len(pocket) ||
+		hasJoker(board) || hasJoker(pocket) {
+		return 0, false
+	}
+	t := getTables()
+
+	boards := make([]partialHand, 0, 20)
+	for itr := Combinations(board, boardCards); itr.HasNext(); {
+		boards = append(boards, t.partial(itr.Next()))
+	}
+
+	best := MakeHandStrength(NoHand, 0, 0, 0)
+	for itr := Combinations(pocket, pocketCards); itr.HasNext(); {
+		cards := itr.Next()
+		ph := t.partial(cards)
+		for _, bh := range boards {
+			var strength HandStrength
+			if ph.suit >= 0 && ph.suit == bh.suit && pocketCards+boardCards == 5 {
+				strength = t.flush[ph.bitSet|bh.bitSet]
+			} else {
+				state := bh.state
+				for _, card := range cards {
+					state = t.next[state*13+uint32(card.Rank)]
+				}
+				strength = t.value[state]
+			}
+			if strength > best {
+				best = strength
+			}
+		}
+	}
+	return best, true
+}
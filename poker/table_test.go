@@ -0,0 +1,112 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func BenchmarkLookupHandStrength7(b *testing.B) {
+	deck := NewDeck()
+	getTables()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Shuffle(deck)
+		lookupHandStrength(deck[:7])
+	}
+}
+
+func TestLookupSmallHands(t *testing.T) {
+	deck := NewDeck()
+	for n := 0; n <= 5; n++ {
+		for itr := Combinations(deck, n); itr.HasNext(); {
+			hand := itr.Next()
+			if strength, _ := lookupHandStrength(hand); strength != calculateHandStrength(hand) {
+				t.Fatalf("%v lookup %#X != %#X", hand, strength, calculateHandStrength(hand))
+			}
+		}
+	}
+}
+
+func TestLookupFallback(t *testing.T) {
+	if _, ok := lookupHandStrength(NewDeck()[:8]); ok {
+		t.Errorf("looked up an 8 card hand")
+	}
+	if _, ok := lookupHandStrength([]Card{Card{Ace, Spades}, Card{Ace, Spades}}); ok {
+		t.Errorf("looked up a hand with duplicate cards")
+	}
+	hand := append(NewDeck()[:6], Card{Two, Clubs})
+	if GetHandStrength(hand) != calculateHandStrength(hand) {
+		t.Errorf("incorrect strength for duplicate cards")
+	}
+}
+
+// TestLookupAllHands compares the lookup tables against calculateHandStrength
+// for all 133,784,560 seven card hands and all six card hands.
+func TestLookupAllHands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping exhaustive comparison in short mode")
+	}
+
+	deck := NewDeck()
+	getTables()
+
+	var wg sync.WaitGroup
+	firsts := make(chan int, len(deck))
+	for i := range deck {
+		firsts <- i
+	}
+	close(firsts)
+
+	failed := make(chan []Card, runtime.GOMAXPROCS(0))
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hand := make([]Card, 7)
+			check := func(hand []Card) bool {
+				strength, _ := lookupHandStrength(hand)
+				if strength != calculateHandStrength(hand) {
+					failed <- append([]Card{}, hand...)
+					return false
+				}
+				return true
+			}
+			for a := range firsts {
+				hand[0] = deck[a]
+				for b := a + 1; b < 52; b++ {
+					hand[1] = deck[b]
+					for c := b + 1; c < 52; c++ {
+						hand[2] = deck[c]
+						for d := c + 1; d < 52; d++ {
+							hand[3] = deck[d]
+							for e := d + 1; e < 52; e++ {
+								hand[4] = deck[e]
+								for f := e + 1; f < 52; f++ {
+									hand[5] = deck[f]
+									if !check(hand[:6]) {
+										return
+									}
+									for g := f + 1; g < 52; g++ {
+										hand[6] = deck[g]
+										if !check(hand) {
+											return
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(failed)
+
+	for hand := range failed {
+		strength, _ := lookupHandStrength(hand)
+		t.Errorf("%v lookup %#X != %#X", hand, strength, calculateHandStrength(hand))
+	}
+}
@@ -0,0 +1,136 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+)
+
+var ThreeCard Evaluator = ThreeCardEvaluator{}
+
+// ThreeCardEvaluator ranks three card hands, as in Three Card Poker and the
+// 21+3 side bet. Three of a kind beats a straight, a straight beats a flush
+// and there is no two pair or full house. A-2-3 is the lowest straight.
+//
+// Strengths are made with the usual hand ranks, so only Compare orders them
+// correctly. Larger hands are ranked by their best three cards.
+type ThreeCardEvaluator struct{}
+
+func (e ThreeCardEvaluator) Evaluate(hand []Card) HandStrength {
+	_, strength := e.BestFive(hand)
+	return strength
+}
+
+// BestFive returns the best three cards in hand.
+func (e ThreeCardEvaluator) BestFive(hand []Card) ([]Card, HandStrength) {
+	if len(hand) <= 3 {
+		return append([]Card{}, hand...), getThreeCardStrength(hand)
+	}
+
+	var best []Card
+	bestStrength := MakeHandStrength(NoHand, 0, 0, 0)
+	for itr := Combinations(hand, 3); itr.HasNext(); {
+		three := itr.Next()
+		if strength := getThreeCardStrength(three); e.Compare(strength, bestStrength) > 0 {
+			best, bestStrength = three, strength
+		}
+	}
+	return best, bestStrength
+}
+
+func getThreeCardStrength(hand []Card) HandStrength {
+	if hasJoker(hand) {
+		return MakeHandStrength(NoHand, 0, 0, 0)
+	}
+
+	var (
+		bitSet    uint16
+		suitMask  uint8
+		rankCount [13]uint8
+	)
+	for _, card := range hand {
+		rankCount[card.Rank]++
+		suitMask |= card.Suit.Mask()
+		bitSet |= card.Rank.Mask()
+	}
+
+	if len(hand) == 3 {
+		strength, straight := findThreeCardStraight(bitSet)
+		flush := suitMask&(suitMask-1) == 0
+		switch {
+		case straight && flush:
+			return MakeHandStrength(StraightFlush, strength, 0, 0)
+		case straight:
+			return MakeHandStrength(Straight, strength, 0, 0)
+		case flush:
+			return MakeHandStrength(Flush, 0, 0, getKickers(bitSet, 3))
+		}
+	}
+
+	// trips / pair
+	for strength := AceHigh; strength > AceLow; strength-- {
+		switch count := rankCount[strength.Rank()]; {
+		case count >= 3:
+			return MakeHandStrength(Trips, strength, 0, 0)
+		case count == 2:
+			return MakeHandStrength(Pair, strength, 0, getKickers(bitSet&^strength.Rank().Mask(), 1))
+		}
+	}
+
+	return MakeHandStrength(HighCard, 0, 0, getKickers(bitSet, 3))
+}
+
+func findThreeCardStraight(bitSet uint16) (CardStrength, bool) {
+	// ace high straight - 0001 1000 0000 0001
+	mask := uint16(0x1801)
+	if (bitSet & mask) == mask {
+		return AceHigh, true
+	}
+
+	for r := King; r >= Three; r-- {
+		mask = uint16(0x0007) << (r - Three)
+		if (bitSet & mask) == mask {
+			return CardStrength(r), true
+		}
+	}
+
+	return 0, false
+}
+
+func (e ThreeCardEvaluator) Compare(a, b HandStrength) int {
+	if ra, rb := e.order(a.Rank()), e.order(b.Rank()); ra != rb {
+		return compareHigh(HandStrength(ra), HandStrength(rb))
+	}
+	return compareHigh(a, b)
+}
+
+// order returns the position of hr in the three card hand rankings.
+func (ThreeCardEvaluator) order(hr HandRank) HandRank {
+	switch hr {
+	case Flush:
+		return TwoPair
+	case Straight:
+		return Trips
+	case Trips:
+		return Straight
+	}
+	return hr
+}
+
+func (ThreeCardEvaluator) Describe(strength HandStrength) string {
+	if strength.Rank() == StraightFlush && strength.strength1() == AceHigh {
+		return "Mini Royal Flush"
+	}
+	return DescribeHigh(strength)
+}
+
+func (e ThreeCardEvaluator) Explain(a, b HandStrength) string {
+	return explain(e, a, b, false)
+}
+
+// Frequencies counts the hands of size cards from deck by hand rank.
+func Frequencies(e Evaluator, deck []Card, size int) map[HandRank]int {
+	counts := make(map[HandRank]int)
+	for itr := Combinations(deck, size); itr.HasNext(); {
+		counts[e.Evaluate(itr.Next()).Rank()]++
+	}
+	return counts
+}
@@ -0,0 +1,65 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"testing"
+)
+
+func TestThreeCardFrequencies(t *testing.T) {
+	counts := Frequencies(ThreeCard, NewDeck(), 3)
+	expected := map[HandRank]int{
+		StraightFlush: 48,
+		Trips:         52,
+		Straight:      720,
+		Flush:         1096,
+		Pair:          3744,
+		HighCard:      16440,
+	}
+	total := 0
+	for rank, count := range counts {
+		if count != expected[rank] {
+			t.Errorf("%d %s hands, expected %d", count, rank, expected[rank])
+		}
+		total += count
+	}
+	if total != 22100 {
+		t.Errorf("%d three card hands, expected 22100", total)
+	}
+}
+
+func TestThreeCard(t *testing.T) {
+	order := []string{
+		"AhKhQh",
+		"2h3hAh",
+		"2s2h2d",
+		"AsKhQd",
+		"2h3dAc",
+		"Kh9h3h",
+		"Kh9h2h",
+		"AsAh2c",
+		"KsKhAc",
+		"AsKhJd",
+	}
+
+	strengths := make([]HandStrength, len(order))
+	for i, s := range order {
+		strengths[i] = ThreeCard.Evaluate(readCards(t, s))
+	}
+	for i := 1; i < len(strengths); i++ {
+		if ThreeCard.Compare(strengths[i-1], strengths[i]) <= 0 || ThreeCard.Compare(strengths[i], strengths[i-1]) >= 0 {
+			t.Errorf("%s does not beat %s", order[i-1], order[i])
+		}
+	}
+
+	cards, strength := ThreeCard.BestFive(readCards(t, "7s8s9sKsKh"))
+	assertCards(t, cards, readCards(t, "7s8s9s"))
+	if strength != MakeHandStrength(StraightFlush, CardStrength(Nine), 0, 0) {
+		t.Errorf("best three card hand %s", DescribeHigh(strength))
+	}
+
+	assertDescription(t, ThreeCard, "AhKhQh", "Mini Royal Flush")
+	assertDescription(t, ThreeCard, "2h3dAc", "Straight, Three high")
+	assertDescription(t, ThreeCard, "Kh9h2h", "Flush, King high")
+	assertDescription(t, ThreeCard, "KsKhAc", "Pair of Kings with an Ace kicker")
+	assertExplanation(t, ThreeCard, "2s2h2d", "AsKhQd", "wins with Three of a Kind, Twos over Straight, Ace high")
+}
@@ -0,0 +1,296 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"math/bits"
+)
+
+func IsJoker(c Card) bool {
+	return c.Rank == Joker
+}
+
+func IsDeuce(c Card) bool {
+	return c.Rank == Two
+}
+
+func IsOneEyedJack(c Card) bool {
+	return c.Rank == Jack && (c.Suit == Spades || c.Suit == Hearts)
+}
+
+var (
+	DeucesWild       Evaluator = WildEvaluator{Wild: IsDeuce}
+	JokersWild       Evaluator = WildEvaluator{Wild: IsJoker}
+	OneEyedJacksWild Evaluator = WildEvaluator{Wild: IsOneEyedJack}
+	BugJoker         Evaluator = WildEvaluator{Wild: IsJoker, Bug: true}
+	JokerLow         Evaluator = JokerLowEvaluator{}
+)
+
+// WildEvaluator ranks high hands where the cards matching Wild, and every
+// joker, can stand for any card. A hand can make five of a kind, which beats
+// a straight flush. With Bug set the wild cards are bugs, which only count
+// as aces or to complete straights and flushes.
+//
+// Strengths made without wild cards are flagged natural. Naturals beat the
+// same hand made with wild cards only if NaturalBeatsWild is set, as with a
+// natural royal flush in video poker.
+type WildEvaluator struct {
+	Wild             func(Card) bool
+	Bug              bool
+	NaturalBeatsWild bool
+}
+
+const naturalFlag = 0x8000
+
+func (hs HandStrength) IsNatural() bool {
+	return hs&naturalFlag != 0
+}
+
+func (e WildEvaluator) isWild(card Card) bool {
+	return card.Rank == Joker || (e.Wild != nil && e.Wild(card))
+}
+
+func (e WildEvaluator) Evaluate(hand []Card) HandStrength {
+	naturals := make([]Card, 0, len(hand))
+	for _, card := range hand {
+		if !e.isWild(card) {
+			naturals = append(naturals, card)
+		}
+	}
+
+	natural := GetHandStrength(naturals)
+	if len(naturals) == len(hand) {
+		return natural | naturalFlag
+	}
+
+	strength := e.calculate(naturals, len(hand)-len(naturals))
+	if strength == natural {
+		strength |= naturalFlag
+	}
+	return strength
+}
+
+// calculate finds the best hand from the natural cards and wilds wild cards.
+// Each hand rank is made with the fewest wild cards, which always leaves the
+// natural cards that are left over as the kickers.
+func (e WildEvaluator) calculate(naturals []Card, wilds int) HandStrength {
+
+	var (
+		bitSet     uint16
+		suitBitSet [4]uint16
+		rankCount  [13]uint8
+		suitCount  [4]uint8
+	)
+
+	for _, card := range naturals {
+		rankCount[card.Rank]++
+		suitCount[card.Suit]++
+		suitBitSet[card.Suit] |= card.Rank.Mask()
+		bitSet |= card.Rank.Mask()
+	}
+
+	// jokers stand for any card, bugs only for aces, straights and flushes
+	cards, jokers, bugs := len(naturals)+wilds, wilds, wilds
+	straightBitSet := bitSet
+	if e.Bug {
+		jokers = 0
+		rankCount[Ace] += uint8(wilds)
+		bitSet |= Ace.Mask()
+	}
+	need := func(strength CardStrength, n int) int {
+		if count := int(rankCount[strength.Rank()]); count < n {
+			return n - count
+		}
+		return 0
+	}
+
+	// five of a kind
+	for strength := AceHigh; strength > AceLow; strength-- {
+		if need(strength, 5) <= jokers {
+			return MakeHandStrength(FiveOfAKind, strength, 0, 0)
+		}
+	}
+
+	// straight flush
+	if cards >= 5 {
+		straights := make([]CardStrength, 0, 4)
+		for _, bitSet := range suitBitSet {
+			if strength, found := findWildStraight(bitSet, bugs); found {
+				straights = append(straights, strength)
+			}
+		}
+		if len(straights) > 0 {
+			return MakeHandStrength(StraightFlush, MaxCardStrength(straights), 0, 0)
+		}
+	}
+
+	// quads
+	for strength := AceHigh; strength > AceLow; strength-- {
+		if need(strength, 4) <= jokers {
+			kickers := getKickers(bitSet&^strength.Rank().Mask(), 1)
+			return MakeHandStrength(Quads, strength, 0, kickers)
+		}
+	}
+
+	// full house
+	for hiStrength := AceHigh; hiStrength > AceLow; hiStrength-- {
+		for loStrength := AceHigh; loStrength > AceLow; loStrength-- {
+			if loStrength != hiStrength && need(hiStrength, 3)+need(loStrength, 2) <= jokers {
+				return MakeHandStrength(FullHouse, hiStrength, loStrength, 0)
+			}
+		}
+	}
+
+	// flush
+	if cards >= 5 {
+		flushKickers := uint16(0)
+		for suit, count := range suitCount {
+			if int(count)+bugs >= 5 {
+				if kickers := getWildKickers(suitBitSet[suit], bugs, 5); kickers > flushKickers {
+					flushKickers = kickers
+				}
+			}
+		}
+		if flushKickers > 0 {
+			return MakeHandStrength(Flush, 0, 0, flushKickers)
+		}
+	}
+
+	// straight
+	if cards >= 5 {
+		if strength, found := findWildStraight(straightBitSet, bugs); found {
+			return MakeHandStrength(Straight, strength, 0, 0)
+		}
+	}
+
+	// trips
+	for strength := AceHigh; strength > AceLow; strength-- {
+		if need(strength, 3) <= jokers {
+			kickers := getKickers(bitSet&^strength.Rank().Mask(), 2)
+			return MakeHandStrength(Trips, strength, 0, kickers)
+		}
+	}
+
+	// two pair / pair
+	for hiStrength := AceHigh; hiStrength > AceLow; hiStrength-- {
+		if need(hiStrength, 2) <= jokers {
+			for loStrength := hiStrength - 1; loStrength > AceLow; loStrength-- {
+				if need(hiStrength, 2)+need(loStrength, 2) <= jokers {
+					kickers := getKickers(bitSet&^(hiStrength.Rank().Mask()|loStrength.Rank().Mask()), 1)
+					return MakeHandStrength(TwoPair, hiStrength, loStrength, kickers)
+				}
+			}
+			kickers := getKickers(bitSet&^hiStrength.Rank().Mask(), 3)
+			return MakeHandStrength(Pair, hiStrength, 0, kickers)
+		}
+	}
+
+	// high card, a lone wild card is an ace
+	if jokers > 0 {
+		return MakeHandStrength(HighCard, 0, 0, AceHigh.Mask())
+	}
+	return MakeHandStrength(HighCard, 0, 0, getKickers(bitSet, 5))
+}
+
+// findWildStraight finds the highest straight with at most wilds cards
+// missing from bitSet.
+func findWildStraight(bitSet uint16, wilds int) (CardStrength, bool) {
+	// ace high straight - 0001 1110 0000 0001
+	mask := uint16(0x1E01)
+	if 5-bits.OnesCount16(bitSet&mask) <= wilds {
+		return AceHigh, true
+	}
+
+	for r := King; r >= Five; r-- {
+		mask = uint16(0x001F) << (r - Five)
+		if 5-bits.OnesCount16(bitSet&mask) <= wilds {
+			return CardStrength(r), true
+		}
+	}
+
+	return 0, false
+}
+
+// getWildKickers takes the highest max kickers, using wild cards for the
+// ranks missing from bitSet.
+func getWildKickers(bitSet uint16, wilds int, max int) uint16 {
+	kickers := uint16(0)
+	found := 0
+	for strength := AceHigh; strength > AceLow && found < max; strength-- {
+		if (bitSet & strength.Rank().Mask()) != 0 {
+			kickers |= strength.Mask()
+			found++
+		} else if wilds > 0 {
+			kickers |= strength.Mask()
+			found++
+			wilds--
+		}
+	}
+	return kickers
+}
+
+func (e WildEvaluator) Compare(a, b HandStrength) int {
+	if c := compareHigh(a&^naturalFlag, b&^naturalFlag); c != 0 || !e.NaturalBeatsWild {
+		return c
+	}
+	return compareHigh(a&naturalFlag, b&naturalFlag)
+}
+
+func (WildEvaluator) Describe(strength HandStrength) string {
+	description := DescribeHigh(strength &^ naturalFlag)
+	if strength.Rank() == StraightFlush && strength.strength1() == AceHigh {
+		if strength.IsNatural() {
+			return "Natural " + description
+		}
+		return "Wild " + description
+	}
+	return description
+}
+
+func (e WildEvaluator) BestFive(hand []Card) ([]Card, HandStrength) {
+	return BestFive(e, hand)
+}
+
+func (e WildEvaluator) Explain(a, b HandStrength) string {
+	return explain(e, a, b, false)
+}
+
+// JokerLowEvaluator ranks ace-to-five low hands with jokers, as in California
+// lowball. Each joker is the lowest rank missing from the hand.
+type JokerLowEvaluator struct{}
+
+func (JokerLowEvaluator) Evaluate(hand []Card) HandStrength {
+	naturals := make([]Card, 0, len(hand))
+	var bitSet uint16
+	for _, card := range hand {
+		if card.Rank != Joker {
+			naturals = append(naturals, card)
+			bitSet |= card.Rank.Mask()
+		}
+	}
+	for jokers := len(hand) - len(naturals); jokers > 0; jokers-- {
+		for _, rank := range Ranks() {
+			if bitSet&rank.Mask() == 0 {
+				naturals = append(naturals, Card{Rank: rank})
+				bitSet |= rank.Mask()
+				break
+			}
+		}
+	}
+	return GetLowHandStrength(naturals, false)
+}
+
+func (JokerLowEvaluator) Compare(a, b HandStrength) int {
+	return compareLow(a, b)
+}
+
+func (JokerLowEvaluator) Describe(strength HandStrength) string {
+	return DescribeLow(strength)
+}
+
+func (e JokerLowEvaluator) BestFive(hand []Card) ([]Card, HandStrength) {
+	return BestFive(e, hand)
+}
+
+func (e JokerLowEvaluator) Explain(a, b HandStrength) string {
+	return explain(e, a, b, true)
+}
@@ -0,0 +1,207 @@
+package poker
+
+import (
+	. "github.com/dohodges/fifty2"
+	"math/bits"
+	"testing"
+)
+
+var bruteDeck = NewDeck()
+
+// bruteWild evaluates a hand by trying every card in place of each wild card
+// from position i on, taking cards from the deck in order from card. Bugs
+// only count if they make an ace, straight or flush.
+func bruteWild(hand []Card, wild func(Card) bool, bug bool, i, card int) HandStrength {
+	for ; i < len(hand); i++ {
+		if !wild(hand[i]) {
+			continue
+		}
+		best := MakeHandStrength(NoHand, 0, 0, 0)
+		sub := append([]Card{}, hand...)
+		for c := card; c < len(bruteDeck); c++ {
+			sub[i] = bruteDeck[c]
+			strength := bruteWild(sub, wild, bug, i+1, c)
+			if bug && sub[i].Rank != Ace && strength.Rank() != Straight && strength.Rank() != Flush && strength.Rank() != StraightFlush {
+				continue
+			}
+			if strength > best {
+				best = strength
+			}
+		}
+		return best
+	}
+
+	var rankCount [13]int
+	for _, card := range hand {
+		if rankCount[card.Rank]++; rankCount[card.Rank] == 5 {
+			if card.Rank == Ace {
+				return MakeHandStrength(FiveOfAKind, AceHigh, 0, 0)
+			}
+			return MakeHandStrength(FiveOfAKind, CardStrength(card.Rank), 0, 0)
+		}
+	}
+	if bits.OnesCount64(Mask(hand)) != len(hand) {
+		return calculateHandStrength(hand)
+	}
+	return GetHandStrength(hand)
+}
+
+func assertWild(t *testing.T, e WildEvaluator, hand []Card) bool {
+	strength := e.Evaluate(hand) &^ naturalFlag
+	if expected := bruteWild(hand, e.isWild, e.Bug, 0, 0); strength != expected {
+		t.Errorf("%v is %s (%#X), expected %s (%#X)", hand, DescribeHigh(strength), strength, DescribeHigh(expected), expected)
+		return false
+	}
+	return true
+}
+
+func TestJokersWild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping exhaustive joker hands in short mode")
+	}
+	for _, e := range []Evaluator{JokersWild, BugJoker} {
+		counts := make(map[HandRank]int)
+		for itr := Combinations(NewJokerDeck(1), 5); itr.HasNext(); {
+			hand := itr.Next()
+			counts[e.Evaluate(hand).Rank()]++
+			if hand[4].Rank == Joker && !assertWild(t, e.(WildEvaluator), hand) {
+				return
+			}
+		}
+		if counts[FiveOfAKind] != 13 && !e.(WildEvaluator).Bug {
+			t.Errorf("%d five of a kind hands, expected 13", counts[FiveOfAKind])
+		}
+		if counts[FiveOfAKind] != 1 && e.(WildEvaluator).Bug {
+			t.Errorf("%d five of a kind hands with a bug, expected 1", counts[FiveOfAKind])
+		}
+	}
+}
+
+func TestDeucesWild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping exhaustive deuces wild hands in short mode")
+	}
+	// every hand from deuces and tens to aces, including all four deuces
+	deck := make([]Card, 0, 24)
+	for _, card := range NewDeck() {
+		if card.Rank == Two || card.Rank == Ace || card.Rank >= Ten {
+			deck = append(deck, card)
+		}
+	}
+	e := DeucesWild.(WildEvaluator)
+	for itr := Combinations(deck, 5); itr.HasNext(); {
+		if !assertWild(t, e, itr.Next()) {
+			return
+		}
+	}
+}
+
+func TestWildHands(t *testing.T) {
+	for _, test := range []struct {
+		e        Evaluator
+		hand     string
+		strength HandStrength
+	}{
+		{DeucesWild, "2s2h2d2cKh", MakeHandStrength(FiveOfAKind, CardStrength(King), 0, 0)},
+		{DeucesWild, "2s2h2d2c", MakeHandStrength(Quads, AceHigh, 0, 0)},
+		{DeucesWild, "2sTh", MakeHandStrength(Pair, CardStrength(Ten), 0, 0)},
+		{DeucesWild, "2s", MakeHandStrength(HighCard, 0, 0, AceHigh.Mask())},
+		{DeucesWild, "2sKhQhJhTh", MakeHandStrength(StraightFlush, AceHigh, 0, 0)},
+		{DeucesWild, "2s3h4h6h7h", MakeHandStrength(StraightFlush, CardStrength(Seven), 0, 0)},
+		{DeucesWild, "2sAh3h7h9h", MakeHandStrength(Flush, 0, 0, AceHigh.Mask()|CardStrength(King).Mask()|CardStrength(Nine).Mask()|CardStrength(Seven).Mask()|CardStrength(Three).Mask())},
+		{JokersWild, "XcKhKsQhQs8c3d", MakeHandStrength(FullHouse, CardStrength(King), CardStrength(Queen), 0)},
+		{JokersWild, "XcXd9h9s7c5d3d", MakeHandStrength(Quads, CardStrength(Nine), 0, CardStrength(Seven).Mask())},
+		{OneEyedJacksWild, "JsJhJdJcAs", MakeHandStrength(Quads, CardStrength(Jack), 0, AceHigh.Mask())},
+		{OneEyedJacksWild, "JsJc9d8c7h", MakeHandStrength(Straight, CardStrength(Jack), 0, 0)},
+		{BugJoker, "XcAsAhAdAc", MakeHandStrength(FiveOfAKind, AceHigh, 0, 0)},
+		{BugJoker, "XcKsKhKdQc", MakeHandStrength(Trips, CardStrength(King), 0, AceHigh.Mask()|CardStrength(Queen).Mask())},
+		{BugJoker, "Xc7s7h4d3c", MakeHandStrength(Pair, CardStrength(Seven), 0, AceHigh.Mask()|CardStrength(Four).Mask()|CardStrength(Three).Mask())},
+		{BugJoker, "Xc8s7h5d4c", MakeHandStrength(Straight, CardStrength(Eight), 0, 0)},
+		{BugJoker, "XcKhQhJhTh", MakeHandStrength(StraightFlush, AceHigh, 0, 0)},
+		{BugJoker, "XcKh9h5h3h", MakeHandStrength(Flush, 0, 0, AceHigh.Mask()|CardStrength(King).Mask()|CardStrength(Nine).Mask()|CardStrength(Five).Mask()|CardStrength(Three).Mask())},
+	} {
+		if strength := test.e.Evaluate(readCards(t, test.hand)) &^ naturalFlag; strength != test.strength {
+			t.Errorf("%s is %s, expected %s", test.hand, DescribeHigh(strength), DescribeHigh(test.strength))
+		}
+	}
+}
+
+func TestWildSevenCards(t *testing.T) {
+	deck := NewJokerDeck(2)
+	source := NewSource(40)
+	for i := 0; i < 2000; i++ {
+		hand := SampleWith(deck, 7, source)
+		_, best := BestFive(BugJoker, hand)
+		if strength := BugJoker.Evaluate(hand); BugJoker.Compare(strength, best) != 0 {
+			t.Fatalf("%v is %s, best five is %s", hand, DescribeHigh(strength), DescribeHigh(best))
+		}
+		_, best = BestFive(JokersWild, hand)
+		if strength := JokersWild.Evaluate(hand); JokersWild.Compare(strength, best) != 0 {
+			t.Fatalf("%v is %s, best five is %s", hand, DescribeHigh(strength), DescribeHigh(best))
+		}
+	}
+}
+
+func TestNaturalHands(t *testing.T) {
+	video := WildEvaluator{Wild: IsDeuce, NaturalBeatsWild: true}
+	natural := video.Evaluate(readCards(t, "AhKhQhJhTh"))
+	wild := video.Evaluate(readCards(t, "2hKhQhJhTh"))
+	if !natural.IsNatural() || wild.IsNatural() {
+		t.Errorf("natural %#X, wild %#X", natural, wild)
+	}
+	if video.Compare(natural, wild) <= 0 || DeucesWild.Compare(natural, wild) != 0 {
+		t.Errorf("natural royal flush compared to wild royal flush")
+	}
+	if description := video.Describe(natural); description != "Natural Royal Flush" {
+		t.Errorf("natural royal flush described as %q", description)
+	}
+	if description := video.Describe(wild); description != "Wild Royal Flush" {
+		t.Errorf("wild royal flush described as %q", description)
+	}
+	if explanation := Explain(video, natural, wild); explanation != "wins with Natural Royal Flush over Wild Royal Flush" {
+		t.Errorf("explanation - %s", explanation)
+	}
+
+	// a joker that can't improve the hand leaves it natural
+	if strength := JokersWild.Evaluate(readCards(t, "XcAhKhQhJhTh")); !strength.IsNatural() {
+		t.Errorf("royal flush with a joker is %s, natural %v", DescribeHigh(strength), strength.IsNatural())
+	}
+	if strength := JokersWild.Evaluate(readCards(t, "XcAhKh9h5h3h")); strength.Rank() != Flush || strength.IsNatural() {
+		t.Errorf("joker flush is %s, natural %v", DescribeHigh(strength), strength.IsNatural())
+	}
+
+	assertDescription(t, DeucesWild, "2s2h2d2cKh", "Five of a Kind, Kings")
+	assertExplanation(t, DeucesWild, "2s2hKdKcKh", "2s2hQdQcQh", "wins with a higher five of a kind (K vs Q)")
+}
+
+func TestNaturalJokers(t *testing.T) {
+	noHand := MakeHandStrength(NoHand, 0, 0, 0)
+	for _, test := range []struct {
+		e    Evaluator
+		hand string
+	}{
+		{High, "XcAsAdKhKs7c3d"},
+		{High, "XcAsAdKhKs"},
+		{High, "XcXdAsKsQsJsTs9s"},
+		{AceToFiveLow, "XcAs2d3h4s"},
+		{AceToFiveLow8, "XcAs2d3h4s7c8d"},
+		{DeuceToSeven, "Xc2s3d4h7s"},
+		{ThreeCard, "XcAsAd"},
+	} {
+		if strength := test.e.Evaluate(readCards(t, test.hand)); strength != noHand {
+			t.Errorf("%s is %#X, expected no hand", test.hand, strength)
+		}
+	}
+
+	if strength := GetLowHandStrength(readCards(t, "XcAs2d3h4s"), false); strength != noHand {
+		t.Errorf("low with a joker is %#X, expected no hand", strength)
+	}
+	if strength := BadugiLow.Evaluate(readCards(t, "XcAs2d3h")); strength.Rank() != HandRank(3) {
+		t.Errorf("badugi with a joker is %#X, expected three cards", strength)
+	}
+	board := readCards(t, "AsKsQsJd2c")
+	omaha := GetGame(Omaha)
+	if strength := omaha.HiStrength(board, readCards(t, "XcTs7h6h")); strength != omaha.HiStrength(board, readCards(t, "Ts7h6h")) {
+		t.Errorf("omaha with a joker is %#X, expected the best hand without it", strength)
+	}
+}
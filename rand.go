@@ -0,0 +1,95 @@
+package fifty2
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+)
+
+// Source is a splitmix64 random source. Unlike the math/rand sources its
+// whole state is a single word, so it can be saved and restored.
+type Source struct {
+	state uint64
+}
+
+func NewSource(seed int64) *Source {
+	return &Source{uint64(seed)}
+}
+
+func (s *Source) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+const golden = 0x9E3779B97F4A7C15
+
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
+
+func (s *Source) Uint64() uint64 {
+	s.state += golden
+	return mix64(s.state)
+}
+
+func (s *Source) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+func (s *Source) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, s.state)
+	return data, nil
+}
+
+func (s *Source) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return fmt.Errorf("fifty2: invalid source state length %d", len(data))
+	}
+	s.state = binary.BigEndian.Uint64(data)
+	return nil
+}
+
+// Stream returns the independent source addressed by path below s, e.g.
+// master.Stream(worker) or master.Stream(hand). It does not advance s, so a
+// stream can be replayed from the master seed alone, whatever other streams
+// were used.
+func (s *Source) Stream(path ...uint64) *Source {
+	state := s.state
+	for _, p := range path {
+		state = mix64(state ^ mix64(p+golden))
+	}
+	return &Source{state}
+}
+
+// Split advances s and returns a new source independent of it.
+func (s *Source) Split() *Source {
+	return &Source{mix64(s.Uint64() ^ 0xD1B54A32D192ED03)}
+}
+
+// ShuffleWith shuffles slice using src, so the same source state always
+// produces the same order.
+func ShuffleWith(slice []Card, src rand.Source) {
+	r := rand.New(src)
+	for i := len(slice) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		slice[i], slice[j] = slice[j], slice[i]
+	}
+}
+
+// SampleWith returns n cards chosen at random from slice using src, leaving
+// slice unchanged.
+func SampleWith(slice []Card, n int, src rand.Source) []Card {
+	if n > len(slice) {
+		panic("fifty2: cannot sample more cards than given card slice")
+	}
+	r := rand.New(src)
+	pool := make([]Card, len(slice))
+	copy(pool, slice)
+	for i := 0; i < n; i++ {
+		j := i + r.Intn(len(pool)-i)
+		pool[i], pool[j] = pool[j], pool[i]
+	}
+	return pool[:n]
+}
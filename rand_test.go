@@ -0,0 +1,71 @@
+package fifty2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSourceStream(t *testing.T) {
+	master := NewSource(2015)
+
+	a := master.Stream(1234567).Uint64()
+	for i := uint64(0); i < 100; i++ {
+		master.Stream(i).Uint64()
+	}
+	if b := master.Stream(1234567).Uint64(); a != b {
+		t.Errorf("stream depends on other streams used")
+	}
+	if b := NewSource(2015).Stream(1234567).Uint64(); a != b {
+		t.Errorf("stream not reproducible from master seed")
+	}
+	if b := NewSource(2016).Stream(1234567).Uint64(); a == b {
+		t.Errorf("stream ignores master seed")
+	}
+
+	if master.Stream(1, 2).Uint64() == master.Stream(2, 1).Uint64() {
+		t.Errorf("stream path order ignored")
+	}
+	if master.Stream(1).Uint64() == master.Stream(2).Uint64() {
+		t.Errorf("sibling streams collide")
+	}
+}
+
+func TestSourceSplit(t *testing.T) {
+	a, b := NewSource(7), NewSource(7)
+	for i := 0; i < 10; i++ {
+		if a.Split().Uint64() != b.Split().Uint64() {
+			t.Fatalf("split %d not deterministic", i)
+		}
+	}
+
+	parent := NewSource(7)
+	child := parent.Split()
+	seen := make(map[uint64]bool)
+	for i := 0; i < 1000; i++ {
+		seen[parent.Uint64()] = true
+	}
+	for i := 0; i < 1000; i++ {
+		if seen[child.Uint64()] {
+			t.Fatalf("split source overlaps its parent")
+		}
+	}
+}
+
+func TestSampleWith(t *testing.T) {
+	deck := NewDeck()
+	sample := SampleWith(deck, 7, NewSource(3).Stream(99))
+	if !reflect.DeepEqual(deck, NewDeck()) {
+		t.Errorf("sampling modified the deck")
+	}
+	if len(sample) != 7 || Mask(sample) == 0 {
+		t.Errorf("incorrect sample - %v", sample)
+	}
+	for i, card := range sample {
+		if Index(sample[i+1:], card) >= 0 {
+			t.Errorf("card sampled twice - %v", sample)
+		}
+	}
+	if again := SampleWith(deck, 7, NewSource(3).Stream(99)); !reflect.DeepEqual(sample, again) {
+		t.Errorf("sample not reproducible - %v %v", sample, again)
+	}
+}
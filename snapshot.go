@@ -0,0 +1,417 @@
+package fifty2
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+)
+
+const snapshotVersion = 1
+
+var snapshotMagic = []byte("F52D")
+
+func cardByte(c Card) byte {
+	return byte(c.Rank)<<2 | byte(c.Suit)
+}
+
+func byteCard(b byte) (Card, error) {
+	card := Card{Rank: Rank(b >> 2), Suit: Suit(b & 3)}
+	if card.Rank > Joker {
+		return Card{}, fmt.Errorf("fifty2: invalid card[%#x]", b)
+	}
+	return card, nil
+}
+
+func (da DealAction) MarshalText() ([]byte, error) {
+	if da > DealBoard {
+		return nil, fmt.Errorf("fifty2: unknown deal action[%d]", da)
+	}
+	return []byte(da.String()), nil
+}
+
+func (da *DealAction) UnmarshalText(text []byte) error {
+	for _, action := range []DealAction{DealDown, DealUp, DealBurn, DealBoard} {
+		if action.String() == string(text) {
+			*da = action
+			return nil
+		}
+	}
+	return fmt.Errorf("fifty2: unknown deal action[%s]", text)
+}
+
+type dealtCardJSON struct {
+	Card Card `json:"card"`
+	Up   bool `json:"up"`
+}
+
+func (dc DealtCard) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dealtCardJSON{dc.Card, dc.FaceUp})
+}
+
+func (dc *DealtCard) UnmarshalJSON(data []byte) error {
+	var dcj dealtCardJSON
+	if err := json.Unmarshal(data, &dcj); err != nil {
+		return err
+	}
+	*dc = DealtCard{dcj.Card, dcj.Up}
+	return nil
+}
+
+// SetSnapshotKey sets the secret key that signs and verifies the dealer's
+// snapshots. Without a key snapshots carry a CRC32 checksum, which detects
+// accidental corruption but not deliberate tampering: anyone can rearrange
+// the undealt cards and recompute it. With a key they carry an HMAC-SHA256
+// instead, and restoring a snapshot needs a dealer with the same key.
+func (d *Dealer) SetSnapshotKey(key []byte) {
+	d.key = append([]byte{}, key...)
+}
+
+// sum returns the checksum of a snapshot, the HMAC of data if the dealer has
+// a key and its CRC32 otherwise.
+func (d *Dealer) sum(data []byte) []byte {
+	if d.key == nil {
+		sum := make([]byte, 4)
+		binary.BigEndian.PutUint32(sum, crc32.ChecksumIEEE(data))
+		return sum
+	}
+	mac := hmac.New(sha256.New, d.key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func (d *Dealer) sumSize() int {
+	if d.key == nil {
+		return 4
+	}
+	return sha256.Size
+}
+
+// MarshalBinary encodes the complete state of the dealer: script, deck order,
+// dealt cards and random source. The encoding is versioned and checksummed,
+// or signed if the dealer has a snapshot key.
+func (d *Dealer) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(snapshotMagic)
+	buf.WriteByte(snapshotVersion)
+	d.encode(&buf)
+	buf.Write(d.sum(buf.Bytes()))
+	return buf.Bytes(), nil
+}
+
+func (d *Dealer) encode(buf *bytes.Buffer) {
+	putUvarint := func(n int) {
+		var b [binary.MaxVarintLen64]byte
+		buf.Write(b[:binary.PutUvarint(b[:], uint64(n))])
+	}
+	putCards := func(cards []Card) {
+		putUvarint(len(cards))
+		for _, card := range cards {
+			buf.WriteByte(cardByte(card))
+		}
+	}
+
+	putUvarint(len(d.script))
+	for _, round := range d.script {
+		putUvarint(len(round))
+		for _, step := range round {
+			buf.WriteByte(byte(step.Action))
+			putUvarint(step.Count)
+		}
+	}
+
+	putCards(d.deck)
+	putUvarint(d.next)
+	putUvarint(d.round)
+
+	putUvarint(len(d.seats))
+	for _, dealt := range d.seats {
+		putUvarint(len(dealt))
+		for _, dc := range dealt {
+			b := cardByte(dc.Card)
+			if dc.FaceUp {
+				b |= 0x80
+			}
+			buf.WriteByte(b)
+		}
+	}
+	putCards(d.board)
+	putCards(d.burned)
+
+	if d.rng == nil {
+		buf.WriteByte(0)
+	} else {
+		state, _ := d.rng.MarshalBinary()
+		buf.WriteByte(1)
+		buf.Write(state)
+	}
+}
+
+func (d *Dealer) UnmarshalBinary(data []byte) error {
+	if len(data) < len(snapshotMagic)+1+d.sumSize() || !bytes.Equal(data[:len(snapshotMagic)], snapshotMagic) {
+		return fmt.Errorf("fifty2: not a dealer snapshot")
+	}
+	if version := data[len(snapshotMagic)]; version != snapshotVersion {
+		return fmt.Errorf("fifty2: unsupported snapshot version %d", version)
+	}
+	body, sum := data[:len(data)-d.sumSize()], data[len(data)-d.sumSize():]
+	if !hmac.Equal(d.sum(body), sum) {
+		return fmt.Errorf("fifty2: snapshot checksum mismatch")
+	}
+
+	sd := snapshotDecoder{data: body[len(snapshotMagic)+1:]}
+	restored, err := sd.decode()
+	if err != nil {
+		return err
+	}
+	restored.key = d.key
+	*d = *restored
+	return nil
+}
+
+type snapshotDecoder struct {
+	data []byte
+	err  error
+}
+
+func (sd *snapshotDecoder) fail(format string, args ...interface{}) {
+	if sd.err == nil {
+		sd.err = fmt.Errorf("fifty2: corrupt snapshot - "+format, args...)
+	}
+}
+
+func (sd *snapshotDecoder) byte() byte {
+	if sd.err != nil {
+		return 0
+	}
+	if len(sd.data) == 0 {
+		sd.fail("unexpected end of data")
+		return 0
+	}
+	b := sd.data[0]
+	sd.data = sd.data[1:]
+	return b
+}
+
+func (sd *snapshotDecoder) uvarint() int {
+	if sd.err != nil {
+		return 0
+	}
+	n, size := binary.Uvarint(sd.data)
+	if size <= 0 || n > uint64(len(sd.data)) {
+		sd.fail("invalid length")
+		return 0
+	}
+	sd.data = sd.data[size:]
+	return int(n)
+}
+
+func (sd *snapshotDecoder) card(b byte) Card {
+	card, err := byteCard(b)
+	if err != nil {
+		sd.fail("%v", err)
+	}
+	return card
+}
+
+func (sd *snapshotDecoder) cards() []Card {
+	cards := make([]Card, 0)
+	for n := sd.uvarint(); n > 0 && sd.err == nil; n-- {
+		cards = append(cards, sd.card(sd.byte()))
+	}
+	return cards
+}
+
+func (sd *snapshotDecoder) decode() (*Dealer, error) {
+	script := make(DealScript, 0)
+	for rounds := sd.uvarint(); rounds > 0 && sd.err == nil; rounds-- {
+		round := make(DealRound, 0)
+		for steps := sd.uvarint(); steps > 0 && sd.err == nil; steps-- {
+			action := DealAction(sd.byte())
+			if action > DealBoard {
+				sd.fail("unknown deal action %d", action)
+			}
+			round = append(round, DealStep{action, sd.uvarint()})
+		}
+		script = append(script, round)
+	}
+
+	snap := dealerSnapshot{Script: script}
+	snap.Deck = sd.cards()
+	snap.Next = sd.uvarint()
+	snap.Round = sd.uvarint()
+
+	snap.Hands = make([][]DealtCard, 0)
+	for seats := sd.uvarint(); seats > 0 && sd.err == nil; seats-- {
+		dealt := make([]DealtCard, 0)
+		for n := sd.uvarint(); n > 0 && sd.err == nil; n-- {
+			b := sd.byte()
+			dealt = append(dealt, DealtCard{sd.card(b &^ 0x80), b&0x80 != 0})
+		}
+		snap.Hands = append(snap.Hands, dealt)
+	}
+	snap.Board = sd.cards()
+	snap.Burned = sd.cards()
+
+	switch sd.byte() {
+	case 0:
+	case 1:
+		if len(sd.data) < 8 {
+			sd.fail("unexpected end of data")
+			break
+		}
+		snap.Source = hex.EncodeToString(sd.data[:8])
+		sd.data = sd.data[8:]
+	default:
+		sd.fail("invalid source flag")
+	}
+
+	if sd.err == nil && len(sd.data) > 0 {
+		sd.fail("%d trailing bytes", len(sd.data))
+	}
+	if sd.err != nil {
+		return nil, sd.err
+	}
+
+	return snap.restore()
+}
+
+type dealerSnapshot struct {
+	Version  int           `json:"version"`
+	Script   DealScript    `json:"script"`
+	Deck     []Card        `json:"deck"`
+	Next     int           `json:"next"`
+	Round    int           `json:"round"`
+	Hands    [][]DealtCard `json:"hands"`
+	Board    []Card        `json:"board"`
+	Burned   []Card        `json:"burned"`
+	Source   string        `json:"source,omitempty"`
+	Checksum string        `json:"checksum"`
+}
+
+// restore rebuilds a dealer by replaying the script over the saved deck and
+// checks the result against the saved dealt cards.
+func (snap *dealerSnapshot) restore() (*Dealer, error) {
+	if len(snap.Hands) == 0 {
+		return nil, fmt.Errorf("fifty2: corrupt snapshot - no seats")
+	}
+	if snap.Round > len(snap.Script) {
+		return nil, fmt.Errorf("fifty2: corrupt snapshot - round %d of %d", snap.Round, len(snap.Script))
+	}
+	for _, round := range snap.Script {
+		for _, step := range round {
+			if step.Count < 0 || step.Count > len(snap.Deck) {
+				return nil, fmt.Errorf("fifty2: corrupt snapshot - invalid step count %d", step.Count)
+			}
+		}
+	}
+
+	d := NewDealer(snap.Script, len(snap.Hands), snap.Deck)
+	for d.round < snap.Round {
+		if err := d.Deal(); err != nil {
+			return nil, fmt.Errorf("fifty2: corrupt snapshot - %v", err)
+		}
+	}
+
+	if d.next != snap.Next {
+		return nil, fmt.Errorf("fifty2: corrupt snapshot - deck position %d, expected %d", snap.Next, d.next)
+	}
+	for seat, dealt := range snap.Hands {
+		if !equalDealt(d.seats[seat], dealt) {
+			return nil, fmt.Errorf("fifty2: corrupt snapshot - seat %d hand does not match deck", seat+1)
+		}
+	}
+	if !equalCards(d.board, snap.Board) {
+		return nil, fmt.Errorf("fifty2: corrupt snapshot - board does not match deck")
+	}
+	if !equalCards(d.burned, snap.Burned) {
+		return nil, fmt.Errorf("fifty2: corrupt snapshot - burn pile does not match deck")
+	}
+
+	if snap.Source != "" {
+		state, err := hex.DecodeString(snap.Source)
+		if err != nil {
+			return nil, fmt.Errorf("fifty2: corrupt snapshot - %v", err)
+		}
+		d.rng = &Source{}
+		if err := d.rng.UnmarshalBinary(state); err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+func equalCards(a, b []Card) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalDealt(a, b []DealtCard) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *Dealer) MarshalJSON() ([]byte, error) {
+	snap := dealerSnapshot{
+		Version: snapshotVersion,
+		Script:  d.script,
+		Deck:    d.deck,
+		Next:    d.next,
+		Round:   d.round,
+		Hands:   d.seats,
+		Board:   d.board,
+		Burned:  d.burned,
+	}
+	if d.rng != nil {
+		state, _ := d.rng.MarshalBinary()
+		snap.Source = hex.EncodeToString(state)
+	}
+	snap.Checksum = hex.EncodeToString(d.checksum())
+	return json.Marshal(snap)
+}
+
+func (d *Dealer) UnmarshalJSON(data []byte) error {
+	var snap dealerSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return err
+	}
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("fifty2: unsupported snapshot version %d", snap.Version)
+	}
+
+	restored, err := snap.restore()
+	if err != nil {
+		return err
+	}
+	restored.key = d.key
+	if sum, err := hex.DecodeString(snap.Checksum); err != nil || !hmac.Equal(restored.checksum(), sum) {
+		return fmt.Errorf("fifty2: snapshot checksum mismatch")
+	}
+	*d = *restored
+	return nil
+}
+
+func (d *Dealer) checksum() []byte {
+	var buf bytes.Buffer
+	d.encode(&buf)
+	return d.sum(buf.Bytes())
+}
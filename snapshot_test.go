@@ -0,0 +1,204 @@
+package fifty2
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func snapshotDealer(t *testing.T) *Dealer {
+	dealer := NewDealer(testHoldemDeal, 3, NewDeck())
+	dealer.Shuffle(NewSource(42))
+	if err := dealer.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dealer.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	return dealer
+}
+
+func assertSameDeal(t *testing.T, expect, actual *Dealer) {
+	for seat := 0; seat < expect.Seats(); seat++ {
+		if !reflect.DeepEqual(expect.DealtCards(seat), actual.DealtCards(seat)) {
+			t.Errorf("seat %d hand %v != %v", seat+1, actual.DealtCards(seat), expect.DealtCards(seat))
+		}
+	}
+	if !reflect.DeepEqual(expect.Board(), actual.Board()) {
+		t.Errorf("board %v != %v", actual.Board(), expect.Board())
+	}
+	if !reflect.DeepEqual(expect.Burned(), actual.Burned()) {
+		t.Errorf("burn pile %v != %v", actual.Burned(), expect.Burned())
+	}
+	if !reflect.DeepEqual(expect.Remaining(), actual.Remaining()) {
+		t.Errorf("remaining deck %v != %v", actual.Remaining(), expect.Remaining())
+	}
+}
+
+func assertContinues(t *testing.T, expect, actual *Dealer) {
+	if err := expect.DealAll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := actual.DealAll(); err != nil {
+		t.Fatal(err)
+	}
+	assertSameDeal(t, expect, actual)
+
+	if expect.Source().Uint64() != actual.Source().Uint64() {
+		t.Errorf("restored source diverged")
+	}
+}
+
+func TestDealerBinarySnapshot(t *testing.T) {
+	dealer := snapshotDealer(t)
+	data, err := dealer.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var restored Dealer
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	assertSameDeal(t, dealer, &restored)
+	assertContinues(t, dealer, &restored)
+}
+
+func TestDealerJSONSnapshot(t *testing.T) {
+	dealer := snapshotDealer(t)
+	data, err := json.Marshal(dealer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := &Dealer{}
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatal(err)
+	}
+	assertSameDeal(t, dealer, restored)
+	assertContinues(t, dealer, restored)
+}
+
+func TestDealerSnapshotCorrupt(t *testing.T) {
+	dealer := snapshotDealer(t)
+	data, _ := dealer.MarshalBinary()
+
+	var restored Dealer
+	for i := range data {
+		corrupt := make([]byte, len(data))
+		copy(corrupt, data)
+		corrupt[i] ^= 0x01
+		if err := restored.UnmarshalBinary(corrupt); err == nil {
+			t.Errorf("accepted snapshot with byte %d corrupted", i)
+		}
+	}
+	if err := restored.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Errorf("accepted truncated snapshot")
+	}
+}
+
+func TestDealerSnapshotTampered(t *testing.T) {
+	dealer := snapshotDealer(t)
+	data, _ := json.Marshal(dealer)
+
+	var snap map[string]interface{}
+	json.Unmarshal(data, &snap)
+
+	// swap a dealt card with one still in the deck
+	board := snap["board"].([]interface{})
+	deck := snap["deck"].([]interface{})
+	board[0], deck[51] = deck[51], board[0]
+	tampered, _ := json.Marshal(snap)
+	if err := json.Unmarshal(tampered, &Dealer{}); err == nil {
+		t.Errorf("accepted snapshot with a swapped board card")
+	}
+
+	// rearrange the undealt cards
+	json.Unmarshal(data, &snap)
+	deck = snap["deck"].([]interface{})
+	deck[50], deck[51] = deck[51], deck[50]
+	tampered, _ = json.Marshal(snap)
+	if err := json.Unmarshal(tampered, &Dealer{}); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("accepted snapshot with a rearranged deck - %v", err)
+	}
+
+	json.Unmarshal(data, &snap)
+	snap["version"] = 2
+	tampered, _ = json.Marshal(snap)
+	if err := json.Unmarshal(tampered, &Dealer{}); err == nil {
+		t.Errorf("accepted unknown snapshot version")
+	}
+}
+
+func TestDealerSnapshotKey(t *testing.T) {
+	key := []byte("secret")
+	dealer := snapshotDealer(t)
+	dealer.SetSnapshotKey(key)
+
+	binary, _ := dealer.MarshalBinary()
+	restored := &Dealer{}
+	restored.SetSnapshotKey(key)
+	if err := restored.UnmarshalBinary(binary); err != nil {
+		t.Fatal(err)
+	}
+	assertSameDeal(t, dealer, restored)
+	if err := (&Dealer{}).UnmarshalBinary(binary); err == nil {
+		t.Errorf("accepted signed snapshot without a key")
+	}
+	other := &Dealer{}
+	other.SetSnapshotKey([]byte("other"))
+	if err := other.UnmarshalBinary(binary); err == nil {
+		t.Errorf("accepted signed snapshot with the wrong key")
+	}
+
+	data, _ := json.Marshal(dealer)
+	restored = &Dealer{}
+	restored.SetSnapshotKey(key)
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatal(err)
+	}
+	assertContinues(t, dealer, restored)
+
+	// rearrange the undealt cards and recompute an unkeyed checksum
+	var snap map[string]interface{}
+	json.Unmarshal(data, &snap)
+	deck := snap["deck"].([]interface{})
+	deck[50], deck[51] = deck[51], deck[50]
+	unkeyed := snapshotDealer(t)
+	unkeyed.deck[50], unkeyed.deck[51] = unkeyed.deck[51], unkeyed.deck[50]
+	snap["checksum"] = hex.EncodeToString(unkeyed.checksum())
+	tampered, _ := json.Marshal(snap)
+	restored = &Dealer{}
+	restored.SetSnapshotKey(key)
+	if err := json.Unmarshal(tampered, restored); err == nil {
+		t.Errorf("accepted a forged snapshot")
+	}
+}
+
+func TestShuffleWith(t *testing.T) {
+	a, b := NewDeck(), NewDeck()
+	ShuffleWith(a, NewSource(7))
+	ShuffleWith(b, NewSource(7))
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("same seed produced different shuffles")
+	}
+	if reflect.DeepEqual(a, NewDeck()) {
+		t.Errorf("deck not shuffled")
+	}
+}
+
+func TestCardText(t *testing.T) {
+	data, err := json.Marshal([]Card{Card{Ace, Spades}, Card{Ten, Diamonds}})
+	if err != nil || string(data) != `["A♠","T♦"]` {
+		t.Errorf("incorrect card json %s - %v", data, err)
+	}
+	var cards []Card
+	if err := json.Unmarshal([]byte(`["as","Td"]`), &cards); err != nil || !reflect.DeepEqual(cards, []Card{Card{Ace, Spades}, Card{Ten, Diamonds}}) {
+		t.Errorf("incorrect cards %v - %v", cards, err)
+	}
+	if err := json.Unmarshal([]byte(`["AsKs"]`), &cards); err == nil {
+		t.Errorf("accepted two cards as one")
+	}
+}
@@ -0,0 +1,274 @@
+package french
+
+import (
+	"fmt"
+	. "github.com/dohodges/fifty2/tarot"
+)
+
+type Contract uint8
+
+const (
+	Pass Contract = iota
+	Prise
+	Garde
+	GardeSans
+	GardeContre
+)
+
+func Contracts() []Contract {
+	return []Contract{Prise, Garde, GardeSans, GardeContre}
+}
+
+func (c Contract) String() string {
+	switch c {
+	case Pass:
+		return "Pass"
+	case Prise:
+		return "Prise"
+	case Garde:
+		return "Garde"
+	case GardeSans:
+		return "Garde Sans"
+	case GardeContre:
+		return "Garde Contre"
+	}
+	return ""
+}
+
+func (c Contract) Multiplier() int {
+	switch c {
+	case Prise:
+		return 1
+	case Garde:
+		return 2
+	case GardeSans:
+		return 4
+	case GardeContre:
+		return 6
+	}
+	return 0
+}
+
+// TakerGetsDog reports whether the dog counts for the taker. In a garde
+// contre it goes to the defence.
+func (c Contract) TakerGetsDog() bool {
+	return c != GardeContre
+}
+
+// DealSizes returns the number of cards dealt to each player and to the dog.
+func DealSizes(players int) (hand, dog int, err error) {
+	switch players {
+	case 3:
+		return 24, 6, nil
+	case 4:
+		return 18, 6, nil
+	case 5:
+		return 15, 3, nil
+	}
+	return 0, 0, fmt.Errorf("french: %d players, expected 3 to 5", players)
+}
+
+// Auction is a single round of bidding, starting with player 0. Each player
+// speaks once and must pass or bid higher than the current contract.
+type Auction struct {
+	players  int
+	bids     []Contract
+	taker    int
+	contract Contract
+}
+
+func NewAuction(players int) (*Auction, error) {
+	if _, _, err := DealSizes(players); err != nil {
+		return nil, err
+	}
+	return &Auction{players: players, bids: make([]Contract, 0, players), taker: -1}, nil
+}
+
+// Next returns the player to speak.
+func (a *Auction) Next() int {
+	return len(a.bids)
+}
+
+func (a *Auction) Done() bool {
+	return len(a.bids) == a.players
+}
+
+func (a *Auction) Bid(c Contract) error {
+	if a.Done() {
+		return fmt.Errorf("french: auction is over")
+	}
+	if c > GardeContre {
+		return fmt.Errorf("french: unknown contract %d", c)
+	}
+	if c != Pass {
+		if c <= a.contract {
+			return fmt.Errorf("french: %s does not overcall %s", c, a.contract)
+		}
+		a.taker = len(a.bids)
+		a.contract = c
+	}
+	a.bids = append(a.bids, c)
+	return nil
+}
+
+func (a *Auction) Bids() []Contract {
+	bids := make([]Contract, len(a.bids))
+	copy(bids, a.bids)
+	return bids
+}
+
+// Winner returns the taker and contract once the auction is over. The hand
+// is redealt if everyone passed.
+func (a *Auction) Winner() (taker int, contract Contract, ok bool) {
+	if !a.Done() || a.taker < 0 {
+		return -1, Pass, false
+	}
+	return a.taker, a.contract, true
+}
+
+// Target returns the points the taker needs with the given number of oudlers.
+func Target(oudlers int) int {
+	switch oudlers {
+	case 0:
+		return 56
+	case 1:
+		return 51
+	case 2:
+		return 41
+	}
+	return 36
+}
+
+type Handful uint8
+
+const (
+	NoHandful Handful = iota
+	SingleHandful
+	DoubleHandful
+	TripleHandful
+)
+
+// Trumps returns the number of trumps that must be shown for the handful.
+func (h Handful) Trumps(players int) int {
+	sizes := map[int][4]int{
+		3: {0, 13, 15, 18},
+		4: {0, 10, 13, 15},
+		5: {0, 8, 10, 13},
+	}
+	if h > TripleHandful {
+		return 0
+	}
+	return sizes[players][h]
+}
+
+func (h Handful) Bonus() int {
+	switch h {
+	case SingleHandful:
+		return 20
+	case DoubleHandful:
+		return 30
+	case TripleHandful:
+		return 40
+	}
+	return 0
+}
+
+type Side uint8
+
+const (
+	Nobody Side = iota
+	Attack
+	Defence
+)
+
+type Result struct {
+	Contract Contract
+	// Tricks is every card won by the attack, including the dog when it
+	// counts for the taker.
+	Tricks      []Card
+	PetitAuBout Side
+	Handful     Handful
+	SlamBid     bool
+	Slam        Side
+}
+
+func (r Result) Oudlers() int {
+	return Oudlers(r.Tricks)
+}
+
+func (r Result) HalfPoints() int {
+	return HalfPoints(r.Tricks)
+}
+
+func (r Result) Made() bool {
+	return r.HalfPoints() >= 2*Target(r.Oudlers())
+}
+
+// Score returns the taker's score against each defender. Half points in the
+// margin are rounded up. The petit au bout goes to the side that won it and
+// the handful to the side that won the contract.
+func (r Result) Score() int {
+	margin := r.HalfPoints() - 2*Target(r.Oudlers())
+	if margin < 0 {
+		margin = -margin
+	}
+
+	score := (25 + (margin+1)/2) * r.Contract.Multiplier()
+	if r.Made() {
+		score += r.Handful.Bonus()
+	} else {
+		score = -score - r.Handful.Bonus()
+	}
+
+	switch r.PetitAuBout {
+	case Attack:
+		score += 10 * r.Contract.Multiplier()
+	case Defence:
+		score -= 10 * r.Contract.Multiplier()
+	}
+
+	switch {
+	case r.Slam == Attack && r.SlamBid:
+		score += 400
+	case r.Slam == Attack:
+		score += 200
+	case r.SlamBid, r.Slam == Defence:
+		score -= 200
+	}
+
+	return score
+}
+
+// Scores splits the taker's score between the players. With 5 players the
+// taker plays with the partner holding the called king and scores double;
+// partner is the taker when the taker called their own king. The scores
+// always sum to zero.
+func Scores(players, taker, partner int, score int) ([]int, error) {
+	if _, _, err := DealSizes(players); err != nil {
+		return nil, err
+	}
+	if taker < 0 || taker >= players {
+		return nil, fmt.Errorf("french: invalid taker %d", taker)
+	}
+
+	scores := make([]int, players)
+	for i := range scores {
+		scores[i] = -score
+	}
+
+	if players == 5 {
+		if partner < 0 || partner >= players {
+			return nil, fmt.Errorf("french: invalid partner %d", partner)
+		}
+		if partner == taker {
+			scores[taker] = 4 * score
+		} else {
+			scores[taker] = 2 * score
+			scores[partner] = score
+		}
+	} else {
+		scores[taker] = (players - 1) * score
+	}
+
+	return scores, nil
+}
@@ -0,0 +1,149 @@
+package french
+
+import (
+	. "github.com/dohodges/fifty2/tarot"
+	"reflect"
+	"testing"
+)
+
+func mustParse(t *testing.T, s string) []Card {
+	cards, err := ParseCards(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cards
+}
+
+func TestAuction(t *testing.T) {
+	auction, _ := NewAuction(4)
+	for _, bid := range []Contract{Prise, Pass, Garde} {
+		if err := auction.Bid(bid); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := auction.Bid(Prise); err == nil {
+		t.Errorf("accepted an underbid")
+	}
+	if _, _, ok := auction.Winner(); ok {
+		t.Errorf("auction won before every player spoke")
+	}
+	auction.Bid(GardeSans)
+	if taker, contract, ok := auction.Winner(); !ok || taker != 3 || contract != GardeSans {
+		t.Errorf("winner %d %s", taker, contract)
+	}
+
+	if _, err := NewAuction(6); err == nil {
+		t.Errorf("accepted 6 players")
+	}
+}
+
+func TestLegalPlays(t *testing.T) {
+	hand := mustParse(t, "3H KH 5T 12T EX 4S")
+
+	assertPlays(t, LegalPlays(hand, nil), hand)
+	assertPlays(t, LegalPlays(hand, mustParse(t, "QH")), mustParse(t, "3H KH EX"))
+	assertPlays(t, LegalPlays(hand, mustParse(t, "EX QH")), mustParse(t, "3H KH EX"))
+	// no clubs, must trump
+	assertPlays(t, LegalPlays(hand, mustParse(t, "QC")), mustParse(t, "5T 12T EX"))
+	// must overtrump
+	assertPlays(t, LegalPlays(hand, mustParse(t, "QC 7T")), mustParse(t, "12T EX"))
+	assertPlays(t, LegalPlays(hand, mustParse(t, "10T")), mustParse(t, "12T EX"))
+	// cannot overtrump, any trump
+	assertPlays(t, LegalPlays(hand, mustParse(t, "QC 15T")), mustParse(t, "5T 12T EX"))
+	// no suit and no trumps
+	assertPlays(t, LegalPlays(mustParse(t, "3H 4S"), mustParse(t, "QC")), mustParse(t, "3H 4S"))
+}
+
+func TestTrickWinner(t *testing.T) {
+	assertWinner(t, "QH KH 3H", 1)
+	assertWinner(t, "QH KS 3H", 0)
+	assertWinner(t, "QH 1T KH", 1)
+	assertWinner(t, "QH 1T 2T", 2)
+	assertWinner(t, "EX 3C KC 21T", 3)
+	assertWinner(t, "EX 3C KC", 2)
+}
+
+func TestScore(t *testing.T) {
+	// 2 oudlers, 49 points in a garde
+	tricks := mustParse(t, "1T 21T KH KS KC QH 2H 3H 4H 5H 6H 7H 8H 9H 10H 1H")
+	for len(tricks) < 60 {
+		tricks = append(tricks, Card{Rank(2 + len(tricks)%8), Spades})
+	}
+	result := Result{Contract: Garde, Tricks: tricks}
+	if result.Oudlers() != 2 || !result.Made() {
+		t.Fatalf("%d oudlers, %d half points", result.Oudlers(), result.HalfPoints())
+	}
+	margin := (result.HalfPoints() - 82 + 1) / 2
+	if score := result.Score(); score != (25+margin)*2 {
+		t.Errorf("score %d, margin %d", score, margin)
+	}
+
+	result.PetitAuBout = Defence
+	result.Handful = SingleHandful
+	if score := result.Score(); score != (25+margin)*2-20+20 {
+		t.Errorf("score %d with petit au bout and handful", score)
+	}
+
+	lost := Result{Contract: Prise, Tricks: mustParse(t, "KH QH"), PetitAuBout: Attack}
+	if score := lost.Score(); score != -(25+(112-16+1)/2)+10 {
+		t.Errorf("lost score %d", score)
+	}
+}
+
+func TestHandfulTrumps(t *testing.T) {
+	for _, test := range []struct {
+		h       Handful
+		players int
+		trumps  int
+	}{
+		{SingleHandful, 4, 10},
+		{TripleHandful, 3, 18},
+		{DoubleHandful, 5, 10},
+		{NoHandful, 4, 0},
+		{SingleHandful, 6, 0},
+		{TripleHandful + 1, 4, 0},
+	} {
+		if trumps := test.h.Trumps(test.players); trumps != test.trumps {
+			t.Errorf("handful %d with %d players is %d trumps, expected %d", test.h, test.players, trumps, test.trumps)
+		}
+	}
+}
+
+func TestScores(t *testing.T) {
+	for players := 3; players <= 5; players++ {
+		for partner := 0; partner < players; partner++ {
+			scores, err := Scores(players, 0, partner, 37)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sum := 0
+			for _, score := range scores {
+				sum += score
+			}
+			if sum != 0 {
+				t.Errorf("%d player scores %v do not sum to zero", players, scores)
+			}
+		}
+	}
+
+	scores, _ := Scores(5, 0, 2, 30)
+	if !reflect.DeepEqual(scores, []int{60, -30, 30, -30, -30}) {
+		t.Errorf("5 player scores %v", scores)
+	}
+	scores, _ = Scores(3, 1, -1, -30)
+	if !reflect.DeepEqual(scores, []int{30, -60, 30}) {
+		t.Errorf("3 player scores %v", scores)
+	}
+}
+
+func assertPlays(t *testing.T, actual, expect []Card) {
+	if !reflect.DeepEqual(actual, expect) {
+		t.Errorf("legal plays %v, expected %v", actual, expect)
+	}
+}
+
+func assertWinner(t *testing.T, trick string, expect int) {
+	if winner := TrickWinner(mustParse(t, trick)); winner != expect {
+		t.Errorf("%s won by %d, expected %d", trick, winner, expect)
+	}
+}
@@ -0,0 +1,93 @@
+package french
+
+import (
+	. "github.com/dohodges/fifty2/tarot"
+)
+
+// led returns the card that sets the suit of the trick. The Excuse never
+// does; when it is led the next card sets the suit.
+func led(trick []Card) (Card, bool) {
+	for _, card := range trick {
+		if card != Excuse {
+			return card, true
+		}
+	}
+	return Card{}, false
+}
+
+func highestTrump(cards []Card) (Rank, bool) {
+	high, found := Rank(0), false
+	for _, card := range cards {
+		if card.IsTrump() && card.Rank > high {
+			high, found = card.Rank, true
+		}
+	}
+	return high, found
+}
+
+// LegalPlays returns the cards in hand that may be played to trick. A player
+// must follow suit, otherwise must trump, and must overtrump the highest
+// trump played if able. The Excuse may always be played.
+func LegalPlays(hand, trick []Card) []Card {
+	lead, ok := led(trick)
+	if !ok {
+		return append([]Card{}, hand...)
+	}
+
+	filter := func(keep func(Card) bool) []Card {
+		legal := make([]Card, 0, len(hand))
+		for _, card := range hand {
+			if card == Excuse || keep(card) {
+				legal = append(legal, card)
+			}
+		}
+		return legal
+	}
+	has := func(keep func(Card) bool) bool {
+		for _, card := range hand {
+			if card != Excuse && keep(card) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !lead.IsTrump() {
+		followSuit := func(c Card) bool { return c.Suit == lead.Suit }
+		if has(followSuit) {
+			return filter(followSuit)
+		}
+	}
+
+	if has(Card.IsTrump) {
+		high, _ := highestTrump(trick)
+		overtrump := func(c Card) bool { return c.IsTrump() && c.Rank > high }
+		if has(overtrump) {
+			return filter(overtrump)
+		}
+		return filter(Card.IsTrump)
+	}
+
+	return append([]Card{}, hand...)
+}
+
+// TrickWinner returns the index of the card that wins trick: the highest
+// trump, or the highest card of the suit led.
+func TrickWinner(trick []Card) int {
+	lead, ok := led(trick)
+	if !ok {
+		return 0
+	}
+
+	winner := Index(trick, lead)
+	for i, card := range trick {
+		best := trick[winner]
+		switch {
+		case card.IsTrump() && (!best.IsTrump() || card.Rank > best.Rank):
+			winner = i
+		case !best.IsTrump() && card.Suit == best.Suit && card.Rank > best.Rank:
+			winner = i
+		}
+	}
+	return winner
+}
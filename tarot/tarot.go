@@ -0,0 +1,262 @@
+package tarot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type Suit uint8
+
+const (
+	Clubs Suit = iota
+	Diamonds
+	Hearts
+	Spades
+	Trumps
+	Fool
+)
+
+func ParseSuit(r rune) (Suit, error) {
+	switch r {
+	case 'c', 'C', '♣':
+		return Clubs, nil
+	case 'd', 'D', '♦':
+		return Diamonds, nil
+	case 'h', 'H', '♥':
+		return Hearts, nil
+	case 's', 'S', '♠':
+		return Spades, nil
+	case 't', 'T':
+		return Trumps, nil
+	}
+	return 0, fmt.Errorf("tarot: unknown suit[%c]", r)
+}
+
+func (s Suit) Rune() rune {
+	switch s {
+	case Clubs:
+		return '♣'
+	case Diamonds:
+		return '♦'
+	case Hearts:
+		return '♥'
+	case Spades:
+		return '♠'
+	case Trumps:
+		return 'T'
+	}
+	return 0
+}
+
+func Suits() []Suit {
+	return []Suit{Clubs, Diamonds, Hearts, Spades}
+}
+
+// Rank is 1-10 or a face card for the four suits and 1-21 for trumps.
+type Rank uint8
+
+const (
+	Jack   Rank = 11
+	Knight Rank = 12
+	Queen  Rank = 13
+	King   Rank = 14
+)
+
+func Ranks() []Rank {
+	ranks := make([]Rank, King)
+	for i := range ranks {
+		ranks[i] = Rank(i + 1)
+	}
+	return ranks
+}
+
+func (r Rank) String() string {
+	switch r {
+	case Jack:
+		return "J"
+	case Knight:
+		return "C"
+	case Queen:
+		return "Q"
+	case King:
+		return "K"
+	}
+	return strconv.Itoa(int(r))
+}
+
+type Card struct {
+	Rank Rank
+	Suit Suit
+}
+
+var (
+	Excuse = Card{0, Fool}
+	Petit  = Card{1, Trumps}
+	Monde  = Card{21, Trumps}
+)
+
+func (c Card) IsTrump() bool {
+	return c.Suit == Trumps
+}
+
+func (c Card) IsOudler() bool {
+	return c == Excuse || c == Petit || c == Monde
+}
+
+func (c Card) IsValid() bool {
+	switch c.Suit {
+	case Clubs, Diamonds, Hearts, Spades:
+		return c.Rank >= 1 && c.Rank <= King
+	case Trumps:
+		return c.Rank >= 1 && c.Rank <= 21
+	case Fool:
+		return c.Rank == 0
+	}
+	return false
+}
+
+// HalfPoints returns the card's value in half points: oudlers and kings are
+// worth 4.5, queens 3.5, knights 2.5, jacks 1.5 and every other card 0.5.
+func (c Card) HalfPoints() int {
+	if c.IsOudler() {
+		return 9
+	}
+	if c.IsTrump() {
+		return 1
+	}
+	switch c.Rank {
+	case King:
+		return 9
+	case Queen:
+		return 7
+	case Knight:
+		return 5
+	case Jack:
+		return 3
+	}
+	return 1
+}
+
+func (c Card) String() string {
+	if c.Suit == Fool {
+		return "EX"
+	}
+	if c.IsTrump() {
+		return fmt.Sprintf("%dT", c.Rank)
+	}
+	return fmt.Sprintf("%s%c", c.Rank, c.Suit.Rune())
+}
+
+// ParseCard parses a card written as rank then suit ("10♥", "CS", "KD"), a
+// trump written as number then T ("21T") or the Excuse ("EX").
+func ParseCard(s string) (Card, error) {
+	if strings.EqualFold(s, "EX") {
+		return Excuse, nil
+	}
+
+	runes := []rune(s)
+	if len(runes) < 2 {
+		return Card{}, fmt.Errorf("tarot: invalid card[%s]", s)
+	}
+	suit, err := ParseSuit(runes[len(runes)-1])
+	if err != nil {
+		return Card{}, err
+	}
+
+	rank := string(runes[:len(runes)-1])
+	card := Card{Suit: suit}
+	if suit != Trumps {
+		switch strings.ToUpper(rank) {
+		case "J", "V":
+			card.Rank = Jack
+		case "C", "N":
+			card.Rank = Knight
+		case "Q", "D":
+			card.Rank = Queen
+		case "K", "R":
+			card.Rank = King
+		}
+	}
+	if card.Rank == 0 {
+		n, err := strconv.Atoi(rank)
+		if err != nil {
+			return Card{}, fmt.Errorf("tarot: unknown rank[%s]", rank)
+		}
+		if n < 0 || n > 21 || (suit != Trumps && n > 10) {
+			return Card{}, fmt.Errorf("tarot: invalid card[%s]", s)
+		}
+		card.Rank = Rank(n)
+	}
+
+	if !card.IsValid() {
+		return Card{}, fmt.Errorf("tarot: invalid card[%s]", s)
+	}
+	return card, nil
+}
+
+// ParseCards parses cards separated by spaces or commas.
+func ParseCards(s string) ([]Card, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	cards := make([]Card, len(fields))
+	for i, field := range fields {
+		card, err := ParseCard(field)
+		if err != nil {
+			return nil, err
+		}
+		cards[i] = card
+	}
+	return cards, nil
+}
+
+func NewDeck() []Card {
+	deck := make([]Card, 0, 78)
+	for _, suit := range Suits() {
+		for _, rank := range Ranks() {
+			deck = append(deck, Card{rank, suit})
+		}
+	}
+	for rank := Rank(1); rank <= 21; rank++ {
+		deck = append(deck, Card{rank, Trumps})
+	}
+	return append(deck, Excuse)
+}
+
+func Index(slice []Card, card Card) int {
+	for i, c := range slice {
+		if c == card {
+			return i
+		}
+	}
+	return -1
+}
+
+func Remove(slice []Card, cards ...Card) []Card {
+	for _, card := range cards {
+		index := Index(slice, card)
+		if index < 0 {
+			continue
+		}
+		slice = append(slice[:index], slice[index+1:]...)
+	}
+	return slice
+}
+
+func HalfPoints(cards []Card) int {
+	points := 0
+	for _, card := range cards {
+		points += card.HalfPoints()
+	}
+	return points
+}
+
+func Oudlers(cards []Card) int {
+	oudlers := 0
+	for _, card := range cards {
+		if card.IsOudler() {
+			oudlers++
+		}
+	}
+	return oudlers
+}
@@ -0,0 +1,53 @@
+package tarot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeck(t *testing.T) {
+	deck := NewDeck()
+	if len(deck) != 78 {
+		t.Errorf("deck has %d cards", len(deck))
+	}
+	if points := HalfPoints(deck); points != 182 {
+		t.Errorf("deck has %d half points, expected 182", points)
+	}
+	if oudlers := Oudlers(deck); oudlers != 3 {
+		t.Errorf("deck has %d oudlers", oudlers)
+	}
+	for i, card := range deck {
+		if !card.IsValid() {
+			t.Errorf("invalid card %v", card)
+		}
+		if Index(deck[i+1:], card) >= 0 {
+			t.Errorf("duplicate card %v", card)
+		}
+		parsed, err := ParseCard(card.String())
+		if err != nil || parsed != card {
+			t.Errorf("%v printed as %s parsed as %v - %v", card, card, parsed, err)
+		}
+	}
+}
+
+func TestParseCards(t *testing.T) {
+	cards, err := ParseCards("10h, CS KD 1t 21T ex vc")
+	expect := []Card{
+		Card{10, Hearts},
+		Card{Knight, Spades},
+		Card{King, Diamonds},
+		Petit,
+		Monde,
+		Excuse,
+		Card{Jack, Clubs},
+	}
+	if err != nil || !reflect.DeepEqual(cards, expect) {
+		t.Errorf("parsed %v - %v", cards, err)
+	}
+
+	for _, invalid := range []string{"11H", "22T", "0T", "KT", "X", "KX"} {
+		if card, err := ParseCard(invalid); err == nil {
+			t.Errorf("parsed %s as %v", invalid, card)
+		}
+	}
+}